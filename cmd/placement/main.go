@@ -13,7 +13,9 @@ import (
 	utilflag "k8s.io/component-base/cli/flag"
 	"k8s.io/component-base/logs"
 
+	"open-cluster-management.io/placement/pkg/cmd/clusterplacements"
 	"open-cluster-management.io/placement/pkg/cmd/hub"
+	"open-cluster-management.io/placement/pkg/cmd/simulate"
 	"open-cluster-management.io/placement/pkg/version"
 )
 
@@ -50,6 +52,8 @@ func newPlacementCommand() *cobra.Command {
 	}
 
 	cmd.AddCommand(hub.NewController())
+	cmd.AddCommand(simulate.NewSimulate())
+	cmd.AddCommand(clusterplacements.NewClusterPlacements())
 
 	return cmd
 }
@@ -0,0 +1,187 @@
+package clusterplacements
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/clientcmd"
+
+	"open-cluster-management.io/placement/pkg/cmd/output"
+	"open-cluster-management.io/placement/pkg/debugger"
+)
+
+// Options defines the flags for the cluster-placements command.
+type Options struct {
+	// Kubeconfig is the path of the kubeconfig file of the hub.
+	Kubeconfig string
+	// Controller is the URL of the secure port of the placement controller.
+	Controller string
+	// InsecureSkipTLSVerify skips the verification of the serving certificate of the controller.
+	InsecureSkipTLSVerify bool
+	// Output is the output format.
+	Output string
+}
+
+// NewClusterPlacements returns the command which prints the placements selecting a cluster.
+func NewClusterPlacements() *cobra.Command {
+	o := &Options{Output: output.Table}
+	cmd := &cobra.Command{
+		Use:   "cluster-placements CLUSTER",
+		Short: "Print the placements which currently select a cluster",
+		Long: "Print the placements which have the cluster in their PlacementDecisions, with the score and the reason " +
+			"of each decision. The placements are queried from the /debug/clusters/<name>/placements endpoint of the " +
+			"controller, which looks up the PlacementDecisions by cluster, with the credentials in the kubeconfig. " +
+			"It is useful before tainting or decommissioning a cluster.",
+		Example: "  kubectl -n open-cluster-management-hub port-forward deploy/cluster-manager-placement-controller 8443\n" +
+			"  placement cluster-placements cluster1 --kubeconfig hub.kubeconfig --controller https://localhost:8443 --insecure-skip-tls-verify",
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return o.Run(cmd, args[0])
+		},
+	}
+
+	o.AddFlags(cmd.Flags())
+
+	return cmd
+}
+
+// AddFlags registers flags for the cluster-placements command.
+func (o *Options) AddFlags(flags *pflag.FlagSet) {
+	flags.StringVar(&o.Kubeconfig, "kubeconfig", o.Kubeconfig,
+		"The path of the kubeconfig file of the hub. The KUBECONFIG environment variable or ~/.kube/config is used if it is not set.")
+	flags.StringVar(&o.Controller, "controller", o.Controller,
+		"The URL of the secure port of the placement controller, e.g. https://localhost:8443 with a port-forward to the controller.")
+	flags.BoolVar(&o.InsecureSkipTLSVerify, "insecure-skip-tls-verify", o.InsecureSkipTLSVerify,
+		"If true, the serving certificate of the controller is not verified, e.g. when it is self-signed.")
+	flags.StringVarP(&o.Output, "output", "o", o.Output,
+		fmt.Sprintf("The output format, one of %v.", output.Formats))
+}
+
+// Run queries the controller and prints the placements selecting the cluster.
+func (o *Options) Run(cmd *cobra.Command, clusterName string) error {
+	if len(o.Controller) == 0 {
+		return fmt.Errorf("the URL of the controller must be specified with --controller")
+	}
+	if err := output.Validate(o.Output); err != nil {
+		return err
+	}
+
+	loadingRules := clientcmd.NewDefaultClientConfigLoadingRules()
+	loadingRules.ExplicitPath = o.Kubeconfig
+	kubeConfig, err := clientcmd.NewNonInteractiveDeferredLoadingClientConfig(loadingRules, &clientcmd.ConfigOverrides{}).ClientConfig()
+	if err != nil {
+		return err
+	}
+
+	// the controller authenticates the credentials of the hub, but serves with its own certificate
+	controllerConfig := rest.CopyConfig(kubeConfig)
+	controllerConfig.Host = o.Controller
+	controllerConfig.ServerName = ""
+	if o.InsecureSkipTLSVerify {
+		controllerConfig.Insecure = true
+		controllerConfig.CAFile = ""
+		controllerConfig.CAData = nil
+	}
+	client, err := rest.HTTPClientFor(controllerConfig)
+	if err != nil {
+		return err
+	}
+
+	result, err := getClusterPlacements(cmd.Context(), client, o.Controller, clusterName)
+	if err != nil {
+		return err
+	}
+
+	return Print(cmd.OutOrStdout(), result, o.Output)
+}
+
+// getClusterPlacements gets the placements selecting the cluster from the debug endpoint of the
+// controller.
+func getClusterPlacements(ctx context.Context, client *http.Client, controller, clusterName string) (debugger.ClusterPlacementsResult, error) {
+	result := debugger.ClusterPlacementsResult{}
+
+	u := strings.TrimSuffix(controller, "/") + debugger.ClusterDebugPath + url.PathEscape(clusterName) + "/placements"
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return result, err
+	}
+	res, err := client.Do(req)
+	if err != nil {
+		return result, err
+	}
+	defer res.Body.Close()
+
+	body, err := ioutil.ReadAll(res.Body)
+	if err != nil {
+		return result, err
+	}
+	if res.StatusCode != http.StatusOK {
+		return result, fmt.Errorf("failed to get the placements of cluster %s from %s: %s %s", clusterName, u, res.Status, string(body))
+	}
+	if err := json.Unmarshal(body, &result); err != nil {
+		return result, fmt.Errorf("failed to decode the placements of cluster %s from %s: %v", clusterName, u, err)
+	}
+	if len(result.Error) > 0 {
+		return result, fmt.Errorf("failed to get the placements of cluster %s: %s", clusterName, result.Error)
+	}
+	return result, nil
+}
+
+// Print writes the placements selecting a cluster in the output format.
+func Print(w io.Writer, result debugger.ClusterPlacementsResult, format string) error {
+	return output.Print(w, result, format, func(w io.Writer) {
+		printTable(w, result)
+	})
+}
+
+// printTable prints a row for each placement selecting the cluster.
+func printTable(w io.Writer, result debugger.ClusterPlacementsResult) {
+	fmt.Fprintf(w, "Cluster %s: selected by %d placements\n", result.Cluster, len(result.Placements))
+	if len(result.Placements) == 0 {
+		return
+	}
+
+	tw := tabwriter.NewWriter(w, 0, 8, 2, ' ', 0)
+	fmt.Fprintln(tw)
+	fmt.Fprintln(tw, "NAMESPACE\tPLACEMENT\tPLACEMENTDECISION\tSCORE\tREASON")
+	for _, p := range result.Placements {
+		score := "-"
+		if p.Score != nil {
+			score = fmt.Sprintf("%d", *p.Score)
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\n", p.Namespace, p.Name, p.PlacementDecision, score, reasonSummary(p))
+	}
+	tw.Flush()
+}
+
+// reasonSummary returns the selection and the prioritizer scores of the decision, or the reason
+// as it is if the decision does not record them.
+func reasonSummary(p debugger.SelectingPlacement) string {
+	if len(p.Selection) == 0 {
+		if len(p.Reason) == 0 {
+			return "-"
+		}
+		return p.Reason
+	}
+
+	prioritizers := []string{}
+	for name, score := range p.Prioritizers {
+		prioritizers = append(prioritizers, fmt.Sprintf("%s=%d", name, score))
+	}
+	if len(prioritizers) == 0 {
+		return p.Selection
+	}
+	sort.Strings(prioritizers)
+	return fmt.Sprintf("%s (%s)", p.Selection, strings.Join(prioritizers, ","))
+}
@@ -0,0 +1,119 @@
+package clusterplacements
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"open-cluster-management.io/placement/pkg/cmd/output"
+	"open-cluster-management.io/placement/pkg/debugger"
+)
+
+func TestGetClusterPlacements(t *testing.T) {
+	score := int64(120)
+	placements := debugger.ClusterPlacementsResult{
+		Cluster: "cluster1",
+		Placements: []debugger.SelectingPlacement{
+			{
+				Namespace:         "ns1",
+				Name:              "placement1",
+				PlacementDecision: "placement1-decision-1",
+				Score:             &score,
+				Selection:         "Steady",
+				Prioritizers:      map[string]int64{"Steady": 20, "Balance": 100},
+			},
+			{
+				Namespace:         "ns2",
+				Name:              "placement2",
+				PlacementDecision: "placement2-decision-1",
+				Reason:            "selected",
+			},
+		},
+	}
+
+	cases := []struct {
+		name           string
+		status         int
+		result         debugger.ClusterPlacementsResult
+		expectedOutput string
+		expectedError  bool
+	}{
+		{
+			name:   "cluster selected by placements",
+			status: http.StatusOK,
+			result: placements,
+			expectedOutput: `Cluster cluster1: selected by 2 placements
+
+NAMESPACE  PLACEMENT   PLACEMENTDECISION      SCORE  REASON
+ns1        placement1  placement1-decision-1  120    Steady (Balance=100,Steady=20)
+ns2        placement2  placement2-decision-1  -      selected
+`,
+		},
+		{
+			name:           "cluster not selected",
+			status:         http.StatusOK,
+			result:         debugger.ClusterPlacementsResult{Cluster: "cluster1", Placements: []debugger.SelectingPlacement{}},
+			expectedOutput: "Cluster cluster1: selected by 0 placements\n",
+		},
+		{
+			name:          "error of the debugger",
+			status:        http.StatusOK,
+			result:        debugger.ClusterPlacementsResult{Error: "failed"},
+			expectedError: true,
+		},
+		{
+			name:          "unauthorized",
+			status:        http.StatusUnauthorized,
+			expectedError: true,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			var path string
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				path = r.URL.Path
+				w.WriteHeader(c.status)
+				body, _ := json.Marshal(c.result)
+				w.Write(body)
+			}))
+			defer server.Close()
+
+			result, err := getClusterPlacements(context.TODO(), server.Client(), server.URL+"/", "cluster1")
+			if path != debugger.ClusterDebugPath+"cluster1/placements" {
+				t.Errorf("expected the placements of cluster1 queried, but got path %s", path)
+			}
+			if c.expectedError {
+				if err == nil {
+					t.Errorf("expected error, but got nil")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected err: %v", err)
+			}
+
+			out := &bytes.Buffer{}
+			if err := Print(out, result, output.Table); err != nil {
+				t.Fatalf("unexpected err: %v", err)
+			}
+			if out.String() != c.expectedOutput {
+				t.Errorf("expected output:\n%s\nbut got:\n%s", c.expectedOutput, out.String())
+			}
+		})
+	}
+}
+
+func TestRun(t *testing.T) {
+	cmd := NewClusterPlacements()
+	cmd.SetArgs([]string{"cluster1"})
+	cmd.SetOut(&bytes.Buffer{})
+	cmd.SetErr(&bytes.Buffer{})
+	if err := cmd.Execute(); err == nil || !strings.Contains(err.Error(), "--controller") {
+		t.Errorf("expected error for the missing controller URL, but got %v", err)
+	}
+}
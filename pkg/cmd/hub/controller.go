@@ -10,11 +10,14 @@ import (
 )
 
 func NewController() *cobra.Command {
+	o := controllers.NewPlacementControllerOptions()
 	cmd := controllercmd.
-		NewControllerCommandConfig("placement", version.Get(), controllers.RunControllerManager).
+		NewControllerCommandConfig("placement", version.Get(), o.RunControllerManager).
 		NewCommand()
 	cmd.Use = "controller"
 	cmd.Short = "Start the Placement Scheduling Controller"
 
+	o.AddFlags(cmd.Flags())
+
 	return cmd
 }
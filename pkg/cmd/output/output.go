@@ -0,0 +1,54 @@
+package output
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"k8s.io/apimachinery/pkg/util/sets"
+	"sigs.k8s.io/yaml"
+)
+
+// The output formats of the commands.
+const (
+	Table = "table"
+	JSON  = "json"
+	YAML  = "yaml"
+)
+
+// Formats are the supported output formats.
+var Formats = []string{Table, JSON, YAML}
+
+// Validate returns an error if the output format is not supported.
+func Validate(format string) error {
+	if !sets.NewString(Formats...).Has(format) {
+		return fmt.Errorf("unsupported output format %q, must be one of %v", format, Formats)
+	}
+	return nil
+}
+
+// Print writes the object in the output format. The object is marshaled for the json and the yaml
+// formats, and printTable writes it in the table format.
+func Print(w io.Writer, obj interface{}, format string, printTable func(w io.Writer)) error {
+	switch format {
+	case JSON:
+		data, err := json.MarshalIndent(obj, "", "  ")
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprintln(w, string(data))
+		return err
+	case YAML:
+		data, err := yaml.Marshal(obj)
+		if err != nil {
+			return err
+		}
+		_, err = w.Write(data)
+		return err
+	case Table:
+		printTable(w)
+		return nil
+	default:
+		return Validate(format)
+	}
+}
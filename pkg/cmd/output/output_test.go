@@ -0,0 +1,61 @@
+package output
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"testing"
+)
+
+func TestPrint(t *testing.T) {
+	obj := map[string]int{"clusters": 2}
+
+	cases := []struct {
+		name          string
+		format        string
+		expected      string
+		expectedError bool
+	}{
+		{
+			name:     "table",
+			format:   Table,
+			expected: "clusters: 2\n",
+		},
+		{
+			name:     "json",
+			format:   JSON,
+			expected: "{\n  \"clusters\": 2\n}\n",
+		},
+		{
+			name:     "yaml",
+			format:   YAML,
+			expected: "clusters: 2\n",
+		},
+		{
+			name:          "unsupported format",
+			format:        "wide",
+			expectedError: true,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			out := &bytes.Buffer{}
+			err := Print(out, obj, c.format, func(w io.Writer) {
+				fmt.Fprintf(w, "clusters: %d\n", obj["clusters"])
+			})
+			if c.expectedError {
+				if err == nil {
+					t.Errorf("expected error, but got output %q", out.String())
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected err: %v", err)
+			}
+			if out.String() != c.expected {
+				t.Errorf("expected output %q, but got %q", c.expected, out.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,92 @@
+package simulate
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	"open-cluster-management.io/placement/pkg/cmd/output"
+	scheduling "open-cluster-management.io/placement/pkg/controllers/scheduling"
+	"open-cluster-management.io/placement/pkg/simulator"
+)
+
+// Options defines the flags for the simulate command.
+type Options struct {
+	// Filenames are the files or directories of the objects to simulate with.
+	Filenames []string
+	// SchedulerConfigFile is the path of the scheduler configuration file.
+	SchedulerConfigFile string
+	// Placements are the namespace/name of the placements to simulate.
+	Placements []string
+	// Output is the output format.
+	Output string
+}
+
+// NewSimulate returns the command which schedules placements offline.
+func NewSimulate() *cobra.Command {
+	o := &Options{Output: output.Table}
+	cmd := &cobra.Command{
+		Use:   "simulate",
+		Short: "Simulate placement decisions offline with the objects in files",
+		Long: "Schedule the placements in the files with ManagedClusters, ManagedClusterSets, ManagedClusterSetBindings, " +
+			"existing PlacementDecisions and AddOnPlacementScores in the files, without connecting to a hub. The " +
+			"decisions, the filter pipeline and the prioritizer scores of each placement are printed.",
+		Example: "  placement simulate -f hub-snapshot/ -f placement.yaml --placement default/placement1 -o yaml",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return o.Run(cmd)
+		},
+	}
+
+	o.AddFlags(cmd.Flags())
+
+	return cmd
+}
+
+// AddFlags registers flags for the simulate command.
+func (o *Options) AddFlags(flags *pflag.FlagSet) {
+	flags.StringArrayVarP(&o.Filenames, "filename", "f", o.Filenames,
+		"The YAML or JSON file, or the directory of the files, containing the objects. Use - to read the standard input.")
+	flags.StringVar(&o.SchedulerConfigFile, "scheduler-config", o.SchedulerConfigFile,
+		"The path of the scheduler configuration file. The default filters and prioritizer weights are used if it is not set.")
+	flags.StringArrayVar(&o.Placements, "placement", o.Placements,
+		"The namespace/name of the placement to simulate. All the placements in the files are simulated if it is not set.")
+	flags.StringVarP(&o.Output, "output", "o", o.Output,
+		fmt.Sprintf("The output format, one of %v.", output.Formats))
+}
+
+// Run simulates the placements and prints the results.
+func (o *Options) Run(cmd *cobra.Command) error {
+	if len(o.Filenames) == 0 {
+		return fmt.Errorf("at least one file must be specified with --filename")
+	}
+	if err := output.Validate(o.Output); err != nil {
+		return err
+	}
+
+	schedulerConfig := scheduling.NewDefaultSchedulerConfiguration()
+	if len(o.SchedulerConfigFile) > 0 {
+		var err error
+		schedulerConfig, err = scheduling.LoadSchedulerConfiguration(o.SchedulerConfigFile)
+		if err != nil {
+			return err
+		}
+	}
+
+	objects, err := simulator.LoadFiles(o.Filenames...)
+	if err != nil {
+		return err
+	}
+
+	s, err := simulator.New(objects, schedulerConfig)
+	if err != nil {
+		return err
+	}
+
+	results, err := s.Simulate(cmd.Context(), o.Placements...)
+	if err != nil {
+		return err
+	}
+
+	return simulator.Print(cmd.OutOrStdout(), results, o.Output)
+}
@@ -83,6 +83,9 @@ func (s *Status) AsError() error {
 
 // Plugin returns the plugin name.
 func (s *Status) Plugin() string {
+	if s == nil {
+		return ""
+	}
 	return s.plugin
 }
 
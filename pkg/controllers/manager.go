@@ -2,25 +2,119 @@ package hub
 
 import (
 	"context"
+	"fmt"
 	"net/http"
 	"time"
 
 	"github.com/openshift/library-go/pkg/controller/controllercmd"
+	"github.com/spf13/pflag"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
 	"k8s.io/apiserver/pkg/server/mux"
+	kubeinformers "k8s.io/client-go/informers"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/tools/events"
+	componenttracing "k8s.io/component-base/tracing"
+	"k8s.io/klog/v2"
 	clusterclient "open-cluster-management.io/api/client/cluster/clientset/versioned"
 	clusterscheme "open-cluster-management.io/api/client/cluster/clientset/versioned/scheme"
 	clusterinformers "open-cluster-management.io/api/client/cluster/informers/externalversions"
+	"open-cluster-management.io/placement/pkg/controllers/metrics"
 	scheduling "open-cluster-management.io/placement/pkg/controllers/scheduling"
+	schedulingcache "open-cluster-management.io/placement/pkg/controllers/scheduling/cache"
+	"open-cluster-management.io/placement/pkg/controllers/tracing"
 	"open-cluster-management.io/placement/pkg/debugger"
+	"open-cluster-management.io/placement/pkg/plugins/clusterhealth"
 )
 
+// PlacementControllerOptions defines the flags for the placement controller.
+type PlacementControllerOptions struct {
+	// SchedulerConfigFile is the path of the scheduler configuration file.
+	SchedulerConfigFile string
+	// SchedulingWorkers is the number of placements synced concurrently.
+	SchedulingWorkers int
+	// DecisionsPerPlacementDecision is the max number of decisions in a placementdecision.
+	DecisionsPerPlacementDecision int
+	// TracingEndpoint is the OTLP gRPC endpoint the traces are exported to. Tracing is disabled
+	// if it is not set.
+	TracingEndpoint string
+	// TracingSamplingRatePerMillion is the number of scheduling cycles traced per million.
+	TracingSamplingRatePerMillion int32
+}
+
+// NewPlacementControllerOptions returns the flags with default values set.
+func NewPlacementControllerOptions() *PlacementControllerOptions {
+	return &PlacementControllerOptions{
+		SchedulingWorkers:             1,
+		DecisionsPerPlacementDecision: 100,
+		TracingSamplingRatePerMillion: 1000000,
+	}
+}
+
+// AddFlags registers flags for the placement controller.
+func (o *PlacementControllerOptions) AddFlags(flags *pflag.FlagSet) {
+	flags.StringVar(&o.SchedulerConfigFile, "scheduler-config", o.SchedulerConfigFile,
+		"The path of the scheduler configuration file. The default filters and prioritizer weights are used if it is not set.")
+	flags.IntVar(&o.SchedulingWorkers, "scheduling-workers", o.SchedulingWorkers,
+		"The number of placements synced concurrently. A placement is never synced by two workers at the same time, "+
+			"and the placements are scheduled one by one with the decisions of the others reserved, while the decisions "+
+			"and the placement status are written concurrently.")
+	flags.IntVar(&o.DecisionsPerPlacementDecision, "decisions-per-placementdecision", o.DecisionsPerPlacementDecision,
+		"The max number of decisions in a PlacementDecision, at most 100. The clusters stay in the PlacementDecisions "+
+			"they are already in, and the decisions are re-sharded only if there are more PlacementDecisions than needed and "+
+			"they are less than half full on average.")
+	flags.StringVar(&o.TracingEndpoint, "tracing-endpoint", o.TracingEndpoint,
+		"The OTLP gRPC endpoint, such as localhost:4317, the traces of the scheduling cycles are exported to. "+
+			"Tracing is disabled if it is not set.")
+	flags.Int32Var(&o.TracingSamplingRatePerMillion, "tracing-sampling-rate-per-million", o.TracingSamplingRatePerMillion,
+		"The number of scheduling cycles traced per million.")
+}
+
 // RunControllerManager starts the controllers on hub to make placement decisions.
 func RunControllerManager(ctx context.Context, controllerContext *controllercmd.ControllerContext) error {
+	return NewPlacementControllerOptions().RunControllerManager(ctx, controllerContext)
+}
+
+// RunControllerManager starts the controllers on hub with the given options.
+func (o *PlacementControllerOptions) RunControllerManager(ctx context.Context, controllerContext *controllercmd.ControllerContext) error {
+	if o.SchedulingWorkers < 1 {
+		return fmt.Errorf("--scheduling-workers must be at least 1, but got %d", o.SchedulingWorkers)
+	}
+	if o.DecisionsPerPlacementDecision < 1 || o.DecisionsPerPlacementDecision > 100 {
+		return fmt.Errorf("--decisions-per-placementdecision must be between 1 and 100, but got %d", o.DecisionsPerPlacementDecision)
+	}
+	if o.TracingSamplingRatePerMillion < 0 || o.TracingSamplingRatePerMillion > 1000000 {
+		return fmt.Errorf("--tracing-sampling-rate-per-million must be between 0 and 1000000, but got %d", o.TracingSamplingRatePerMillion)
+	}
+
+	schedulerConfig := scheduling.NewDefaultSchedulerConfiguration()
+	if len(o.SchedulerConfigFile) > 0 {
+		var err error
+		schedulerConfig, err = scheduling.LoadSchedulerConfiguration(o.SchedulerConfigFile)
+		if err != nil {
+			return err
+		}
+	}
+
 	kubeConf := controllerContext.KubeConfig
 	kubeConf.QPS = 50
 	kubeConf.Burst = 100
+	if len(o.TracingEndpoint) > 0 {
+		tp, err := tracing.NewProvider(ctx, o.TracingEndpoint, o.TracingSamplingRatePerMillion)
+		if err != nil {
+			return err
+		}
+		defer func() {
+			// flush the spans with a fresh context since ctx is done
+			shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+			if err := tp.Shutdown(shutdownCtx); err != nil {
+				klog.Errorf("failed to shut down the tracer provider: %v", err)
+			}
+		}()
+		// the requests to the hub are traced as the children of the scheduling spans
+		kubeConf.Wrap(componenttracing.WrapperFor(tp))
+	}
 	clusterClient, err := clusterclient.NewForConfig(controllerContext.KubeConfig)
 	if err != nil {
 		return err
@@ -33,26 +127,50 @@ func RunControllerManager(ctx context.Context, controllerContext *controllercmd.
 
 	clusterInformers := clusterinformers.NewSharedInformerFactory(clusterClient, 10*time.Minute)
 
+	// only the leases of the managed clusters are watched
+	leaseInformers := kubeinformers.NewSharedInformerFactoryWithOptions(kubeClient, 10*time.Minute,
+		kubeinformers.WithTweakListOptions(func(options *metav1.ListOptions) {
+			options.FieldSelector = fields.OneTermEqualSelector("metadata.name", clusterhealth.LeaseName).String()
+		}))
+
 	broadcaster := events.NewBroadcaster(&events.EventSinkImpl{Interface: kubeClient.EventsV1()})
 
 	broadcaster.StartRecordingToSink(ctx.Done())
 
 	recorder := broadcaster.NewRecorder(clusterscheme.Scheme, "placementController")
 
-	scheduler := scheduling.NewPluginScheduler(
+	// the decision cache is shared by the plugins and kept up to date with the placementdecisions
+	decisionCache := schedulingcache.NewCache()
+	if _, err := clusterInformers.Cluster().V1beta1().PlacementDecisions().Informer().AddEventHandler(decisionCache); err != nil {
+		return err
+	}
+
+	scheduler, err := scheduling.NewPluginSchedulerWithConfig(
 		scheduling.NewSchedulerHandler(
 			clusterClient,
 			clusterInformers.Cluster().V1beta1().PlacementDecisions().Lister(),
 			clusterInformers.Cluster().V1alpha1().AddOnPlacementScores().Lister(),
 			clusterInformers.Cluster().V1().ManagedClusters().Lister(),
+			leaseInformers.Coordination().V1().Leases().Lister(),
+			decisionCache,
 			recorder),
+		schedulerConfig,
 	)
+	if err != nil {
+		return err
+	}
+
+	// the metrics are served by the metrics server of the controller
+	metrics.Register()
 
 	if controllerContext.Server != nil {
 		debug := debugger.NewDebugger(
 			scheduler,
 			clusterInformers.Cluster().V1beta1().Placements(),
 			clusterInformers.Cluster().V1().ManagedClusters(),
+			clusterInformers.Cluster().V1beta2().ManagedClusterSets(),
+			clusterInformers.Cluster().V1beta2().ManagedClusterSetBindings(),
+			clusterInformers.Cluster().V1beta1().PlacementDecisions(),
 		)
 
 		installDebugger(controllerContext.Server.Handler.NonGoRestfulMux, debug)
@@ -67,12 +185,14 @@ func RunControllerManager(ctx context.Context, controllerContext *controllercmd.
 		clusterInformers.Cluster().V1beta1().PlacementDecisions(),
 		clusterInformers.Cluster().V1alpha1().AddOnPlacementScores(),
 		scheduler,
+		o.DecisionsPerPlacementDecision,
 		controllerContext.EventRecorder, recorder,
 	)
 
 	go clusterInformers.Start(ctx.Done())
+	go leaseInformers.Start(ctx.Done())
 
-	go schedulingController.Run(ctx, 1)
+	go schedulingController.Run(ctx, o.SchedulingWorkers)
 
 	<-ctx.Done()
 	return nil
@@ -80,4 +200,5 @@ func RunControllerManager(ctx context.Context, controllerContext *controllercmd.
 
 func installDebugger(mux *mux.PathRecorderMux, d *debugger.Debugger) {
 	mux.HandlePrefix(debugger.DebugPath, http.HandlerFunc(d.Handler))
+	mux.HandlePrefix(debugger.ClusterDebugPath, http.HandlerFunc(d.ClusterHandler))
 }
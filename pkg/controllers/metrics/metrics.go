@@ -0,0 +1,208 @@
+package metrics
+
+import (
+	"sync"
+	"time"
+
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/component-base/metrics"
+	"k8s.io/component-base/metrics/legacyregistry"
+
+	"open-cluster-management.io/placement/pkg/controllers/framework"
+)
+
+const subsystem = "placement"
+
+// The extension points of the plugins in the plugin duration metric.
+const (
+	ExtensionPointFilter = "Filter"
+	ExtensionPointScore  = "Score"
+)
+
+// The operations on placementdecisions in the placementdecision operation metric.
+const (
+	OperationCreate = "create"
+	OperationUpdate = "update"
+	OperationDelete = "delete"
+)
+
+var (
+	syncDuration = metrics.NewHistogram(
+		&metrics.HistogramOpts{
+			Subsystem:      subsystem,
+			Name:           "sync_duration_seconds",
+			Help:           "Latency of syncing a placement, from reading the placement to updating its status.",
+			Buckets:        metrics.ExponentialBuckets(0.001, 2, 15),
+			StabilityLevel: metrics.ALPHA,
+		},
+	)
+
+	pluginDuration = metrics.NewHistogramVec(
+		&metrics.HistogramOpts{
+			Subsystem: subsystem,
+			Name:      "plugin_duration_seconds",
+			Help: "Duration of running a plugin at an extension point. A plugin independent per cluster " +
+				"may run on chunks of the clusters, and each chunk is observed separately.",
+			Buckets:        metrics.ExponentialBuckets(0.0001, 2, 15),
+			StabilityLevel: metrics.ALPHA,
+		},
+		[]string{"plugin", "extension_point"},
+	)
+
+	decisionChanges = metrics.NewCounterVec(
+		&metrics.CounterOpts{
+			Subsystem:      subsystem,
+			Name:           "decision_changes_total",
+			Help:           "Number of clusters added to or removed from the decisions of a placement.",
+			StabilityLevel: metrics.ALPHA,
+		},
+		[]string{"namespace", "placement", "change"},
+	)
+
+	placementDecisionOperations = metrics.NewCounterVec(
+		&metrics.CounterOpts{
+			Subsystem:      subsystem,
+			Name:           "placementdecision_operations_total",
+			Help:           "Number of placementdecisions created, updated or deleted by the scheduler.",
+			StabilityLevel: metrics.ALPHA,
+		},
+		[]string{"operation"},
+	)
+
+	scheduleFailures = metrics.NewCounterVec(
+		&metrics.CounterOpts{
+			Subsystem:      subsystem,
+			Name:           "schedule_failures_total",
+			Help:           "Number of schedulings which end with a Misconfigured or an Error status.",
+			StabilityLevel: metrics.ALPHA,
+		},
+		[]string{"result"},
+	)
+
+	expiredAddOnScores = metrics.NewCounter(
+		&metrics.CounterOpts{
+			Subsystem:      subsystem,
+			Name:           "expired_addon_scores_total",
+			Help:           "Number of expired addonplacementscores skipped when scoring clusters.",
+			StabilityLevel: metrics.ALPHA,
+		},
+	)
+
+	unsatisfiedPlacements = metrics.NewGauge(
+		&metrics.GaugeOpts{
+			Subsystem:      subsystem,
+			Name:           "unsatisfied_placements",
+			Help:           "Number of placements whose PlacementSatisfied condition is not true.",
+			StabilityLevel: metrics.ALPHA,
+		},
+	)
+
+	queueDepth = metrics.NewGauge(
+		&metrics.GaugeOpts{
+			Subsystem:      subsystem,
+			Name:           "queue_depth",
+			Help:           "Number of placements waiting in the queue of the scheduling controller.",
+			StabilityLevel: metrics.ALPHA,
+		},
+	)
+
+	collectors = []metrics.Registerable{
+		syncDuration,
+		pluginDuration,
+		decisionChanges,
+		placementDecisionOperations,
+		scheduleFailures,
+		expiredAddOnScores,
+		unsatisfiedPlacements,
+		queueDepth,
+	}
+
+	registerOnce sync.Once
+
+	// unsatisfied are the keys of the unsatisfied placements
+	unsatisfied     = map[string]struct{}{}
+	unsatisfiedLock sync.Mutex
+)
+
+// Register registers the metrics to the legacy registry, which is served by the metrics server
+// of the controller. It is safe to call it more than once.
+func Register() {
+	registerOnce.Do(func() {
+		for _, c := range collectors {
+			legacyregistry.MustRegister(c)
+		}
+	})
+}
+
+// ObserveSyncDuration records the duration of syncing a placement since the start time.
+func ObserveSyncDuration(start time.Time) {
+	syncDuration.Observe(time.Since(start).Seconds())
+}
+
+// ObservePluginDuration records the duration of running a plugin since the start time.
+func ObservePluginDuration(plugin, extensionPoint string, start time.Time) {
+	pluginDuration.WithLabelValues(plugin, extensionPoint).Observe(time.Since(start).Seconds())
+}
+
+// RecordDecisionChanges records the numbers of clusters added to and removed from the decisions
+// of a placement.
+func RecordDecisionChanges(namespace, name string, added, removed int) {
+	if added > 0 {
+		decisionChanges.WithLabelValues(namespace, name, "added").Add(float64(added))
+	}
+	if removed > 0 {
+		decisionChanges.WithLabelValues(namespace, name, "removed").Add(float64(removed))
+	}
+}
+
+// RecordPlacementDecisionOperation records a successful operation on a placementdecision.
+func RecordPlacementDecisionOperation(operation string) {
+	placementDecisionOperations.WithLabelValues(operation).Inc()
+}
+
+// RecordScheduleStatus records the status of a scheduling if it is Misconfigured or Error.
+func RecordScheduleStatus(status *framework.Status) {
+	switch status.Code() {
+	case framework.Misconfigured:
+		scheduleFailures.WithLabelValues("Misconfigured").Inc()
+	case framework.Error:
+		scheduleFailures.WithLabelValues("Error").Inc()
+	}
+}
+
+// RecordExpiredAddOnScores records the number of expired addonplacementscores.
+func RecordExpiredAddOnScores(count int) {
+	expiredAddOnScores.Add(float64(count))
+}
+
+// SetPlacementSatisfied tracks whether the placement with the key is satisfied.
+func SetPlacementSatisfied(key string, satisfied bool) {
+	unsatisfiedLock.Lock()
+	defer unsatisfiedLock.Unlock()
+
+	if satisfied {
+		delete(unsatisfied, key)
+	} else {
+		unsatisfied[key] = struct{}{}
+	}
+	unsatisfiedPlacements.Set(float64(len(unsatisfied)))
+}
+
+// DeletePlacement stops tracking the placement with the key once it is deleted, and removes the
+// decision changes of the placement.
+func DeletePlacement(key string) {
+	SetPlacementSatisfied(key, true)
+
+	namespace, name, err := cache.SplitMetaNamespaceKey(key)
+	if err != nil {
+		return
+	}
+	for _, change := range []string{"added", "removed"} {
+		decisionChanges.Delete(map[string]string{"namespace": namespace, "placement": name, "change": change})
+	}
+}
+
+// SetQueueDepth records the number of placements waiting in the queue.
+func SetQueueDepth(depth int) {
+	queueDepth.Set(float64(depth))
+}
@@ -0,0 +1,138 @@
+package metrics
+
+import (
+	"testing"
+	"time"
+
+	"k8s.io/component-base/metrics/testutil"
+
+	"open-cluster-management.io/placement/pkg/controllers/framework"
+)
+
+func TestRecordMetrics(t *testing.T) {
+	Register()
+	// it is safe to register more than once
+	Register()
+
+	RecordDecisionChanges("default", "placement1", 2, 0)
+	RecordDecisionChanges("default", "placement1", 1, 1)
+	RecordDecisionChanges("default", "placement3", 2, 1)
+	RecordPlacementDecisionOperation(OperationCreate)
+	RecordScheduleStatus(framework.NewStatus("plugin", framework.Misconfigured, "bad"))
+	RecordScheduleStatus(framework.NewStatus("plugin", framework.Warning, "warn"))
+	RecordScheduleStatus(nil)
+	RecordExpiredAddOnScores(3)
+	ObserveSyncDuration(time.Now())
+	ObservePluginDuration("Predicate", ExtensionPointFilter, time.Now())
+	SetQueueDepth(5)
+
+	SetPlacementSatisfied("default/placement1", false)
+	SetPlacementSatisfied("default/placement2", false)
+	SetPlacementSatisfied("default/placement2", false)
+	SetPlacementSatisfied("default/placement1", true)
+	DeletePlacement("default/placement3")
+
+	cases := []struct {
+		name     string
+		getValue func() (float64, error)
+		expected float64
+	}{
+		{
+			name: "decisions added",
+			getValue: func() (float64, error) {
+				return testutil.GetCounterMetricValue(decisionChanges.WithLabelValues("default", "placement1", "added"))
+			},
+			expected: 3,
+		},
+		{
+			name: "decisions removed",
+			getValue: func() (float64, error) {
+				return testutil.GetCounterMetricValue(decisionChanges.WithLabelValues("default", "placement1", "removed"))
+			},
+			expected: 1,
+		},
+		{
+			name: "decision changes of a deleted placement",
+			getValue: func() (float64, error) {
+				count := 0
+				for _, change := range []string{"added", "removed"} {
+					if decisionChanges.Delete(map[string]string{"namespace": "default", "placement": "placement3", "change": change}) {
+						count++
+					}
+				}
+				return float64(count), nil
+			},
+			expected: 0,
+		},
+		{
+			name: "placementdecisions created",
+			getValue: func() (float64, error) {
+				return testutil.GetCounterMetricValue(placementDecisionOperations.WithLabelValues(OperationCreate))
+			},
+			expected: 1,
+		},
+		{
+			name: "misconfigured schedulings",
+			getValue: func() (float64, error) {
+				return testutil.GetCounterMetricValue(scheduleFailures.WithLabelValues("Misconfigured"))
+			},
+			expected: 1,
+		},
+		{
+			name: "failed schedulings",
+			getValue: func() (float64, error) {
+				return testutil.GetCounterMetricValue(scheduleFailures.WithLabelValues("Error"))
+			},
+			expected: 0,
+		},
+		{
+			name: "expired addon scores",
+			getValue: func() (float64, error) {
+				return testutil.GetCounterMetricValue(expiredAddOnScores)
+			},
+			expected: 3,
+		},
+		{
+			name: "sync duration",
+			getValue: func() (float64, error) {
+				count, err := testutil.GetHistogramMetricCount(syncDuration.ObserverMetric)
+				return float64(count), err
+			},
+			expected: 1,
+		},
+		{
+			name: "plugin duration",
+			getValue: func() (float64, error) {
+				count, err := testutil.GetHistogramMetricCount(pluginDuration.WithLabelValues("Predicate", ExtensionPointFilter))
+				return float64(count), err
+			},
+			expected: 1,
+		},
+		{
+			name: "queue depth",
+			getValue: func() (float64, error) {
+				return testutil.GetGaugeMetricValue(queueDepth)
+			},
+			expected: 5,
+		},
+		{
+			name: "unsatisfied placements",
+			getValue: func() (float64, error) {
+				return testutil.GetGaugeMetricValue(unsatisfiedPlacements)
+			},
+			expected: 1,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			actual, err := c.getValue()
+			if err != nil {
+				t.Fatalf("unexpected err: %v", err)
+			}
+			if actual != c.expected {
+				t.Errorf("expected %v, but got %v", c.expected, actual)
+			}
+		})
+	}
+}
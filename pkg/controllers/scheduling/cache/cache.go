@@ -0,0 +1,228 @@
+package cache
+
+import (
+	"sync"
+	"time"
+
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	kcache "k8s.io/client-go/tools/cache"
+	"k8s.io/klog/v2"
+	clusterapiv1beta1 "open-cluster-management.io/api/cluster/v1beta1"
+)
+
+const placementLabel = "cluster.open-cluster-management.io/placement"
+
+// AssumedDecisionTTL is how long the assumed decisions of a placement are kept if they are never
+// observed in the informer events, e.g. the placement is deleted before they are written.
+var AssumedDecisionTTL = 30 * time.Second
+
+// decisionObject is the cached content of a placementdecision.
+type decisionObject struct {
+	placement types.NamespacedName
+	clusters  []string
+}
+
+type assumedDecisions struct {
+	clusters  sets.String
+	assumedAt time.Time
+}
+
+// Cache maintains the decisions of all the placements from the placementdecision informer
+// events. It also keeps an assumed layer for the decisions which are scheduled but not yet
+// written, the assumed decisions of a placement replace its written ones until they are
+// observed or forgotten.
+type Cache struct {
+	lock sync.Mutex
+	// objects are the placementdecisions keyed by namespace/name.
+	objects map[string]*decisionObject
+	// placementObjects indexes the keys of the placementdecisions by placement.
+	placementObjects map[types.NamespacedName]sets.String
+	assumed          map[types.NamespacedName]*assumedDecisions
+	// generation is increased on each change, the snapshot is rebuilt if it is outdated.
+	generation         int64
+	snapshotGeneration int64
+	// snapshot is the last snapshot taken, it is returned again until the cache changes or
+	// the first assumed decisions in it expire at snapshotExpiry.
+	snapshot       *Snapshot
+	snapshotExpiry time.Time
+}
+
+var _ kcache.ResourceEventHandler = &Cache{}
+
+// NewCache returns an empty cache.
+func NewCache() *Cache {
+	return &Cache{
+		objects:            map[string]*decisionObject{},
+		placementObjects:   map[types.NamespacedName]sets.String{},
+		assumed:            map[types.NamespacedName]*assumedDecisions{},
+		snapshotGeneration: -1,
+	}
+}
+
+// OnAdd adds the placementdecision to the cache.
+func (c *Cache) OnAdd(obj interface{}) {
+	decision, ok := obj.(*clusterapiv1beta1.PlacementDecision)
+	if !ok {
+		return
+	}
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.setObject(decision)
+}
+
+// OnUpdate updates the placementdecision in the cache.
+func (c *Cache) OnUpdate(oldObj, newObj interface{}) {
+	c.OnAdd(newObj)
+}
+
+// OnDelete removes the placementdecision from the cache.
+func (c *Cache) OnDelete(obj interface{}) {
+	if tombstone, ok := obj.(kcache.DeletedFinalStateUnknown); ok {
+		obj = tombstone.Obj
+	}
+	decision, ok := obj.(*clusterapiv1beta1.PlacementDecision)
+	if !ok {
+		return
+	}
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.deleteObject(decision.Namespace + "/" + decision.Name)
+}
+
+func (c *Cache) setObject(decision *clusterapiv1beta1.PlacementDecision) {
+	key := decision.Namespace + "/" + decision.Name
+	c.deleteObject(key)
+
+	// a placementdecision without the placement label is counted as a placement on its own
+	placementName, ok := decision.Labels[placementLabel]
+	if !ok {
+		placementName = decision.Name
+	}
+	obj := &decisionObject{
+		placement: types.NamespacedName{Namespace: decision.Namespace, Name: placementName},
+	}
+	for _, d := range decision.Status.Decisions {
+		obj.clusters = append(obj.clusters, d.ClusterName)
+	}
+	c.objects[key] = obj
+	if c.placementObjects[obj.placement] == nil {
+		c.placementObjects[obj.placement] = sets.NewString()
+	}
+	c.placementObjects[obj.placement].Insert(key)
+
+	c.confirm(obj.placement)
+	c.generation++
+}
+
+func (c *Cache) deleteObject(key string) {
+	obj, ok := c.objects[key]
+	if !ok {
+		return
+	}
+	delete(c.objects, key)
+	c.placementObjects[obj.placement].Delete(key)
+	if c.placementObjects[obj.placement].Len() == 0 {
+		delete(c.placementObjects, obj.placement)
+	}
+
+	c.confirm(obj.placement)
+	c.generation++
+}
+
+// writtenClusters returns the clusters in the written decisions of the placement.
+func (c *Cache) writtenClusters(placement types.NamespacedName) sets.String {
+	clusters := sets.NewString()
+	for key := range c.placementObjects[placement] {
+		clusters.Insert(c.objects[key].clusters...)
+	}
+	return clusters
+}
+
+// confirm drops the assumed decisions of the placement if they are written.
+func (c *Cache) confirm(placement types.NamespacedName) {
+	assumed, ok := c.assumed[placement]
+	if !ok {
+		return
+	}
+	if assumed.clusters.Equal(c.writtenClusters(placement)) {
+		klog.V(4).Infof("Assumed decisions of placement %s are observed", placement)
+		delete(c.assumed, placement)
+	}
+}
+
+// Assume marks the decisions of the placement as scheduled but not yet written. They are seen
+// by the next snapshots in place of the written decisions of the placement. The expired assumed
+// decisions of other placements are dropped.
+func (c *Cache) Assume(placement *clusterapiv1beta1.Placement, decisions []clusterapiv1beta1.ClusterDecision) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	for key, assumed := range c.assumed {
+		if time.Since(assumed.assumedAt) > AssumedDecisionTTL {
+			klog.V(4).Infof("Assumed decisions of placement %s expired", key)
+			delete(c.assumed, key)
+		}
+	}
+
+	key := types.NamespacedName{Namespace: placement.Namespace, Name: placement.Name}
+	clusters := sets.NewString()
+	for _, d := range decisions {
+		clusters.Insert(d.ClusterName)
+	}
+	c.assumed[key] = &assumedDecisions{clusters: clusters, assumedAt: time.Now()}
+	c.confirm(key)
+	c.generation++
+}
+
+// Forget drops the assumed decisions of the placement, e.g. if they fail to be written.
+func (c *Cache) Forget(placement *clusterapiv1beta1.Placement) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	key := types.NamespacedName{Namespace: placement.Namespace, Name: placement.Name}
+	if _, ok := c.assumed[key]; ok {
+		delete(c.assumed, key)
+		c.generation++
+	}
+}
+
+// Snapshot returns a snapshot of the current decisions, in which the expired assumed decisions
+// are replaced by the written ones. A new snapshot is taken only if the cache is changed since
+// the last one. The scheduler takes one at the beginning of each scheduling cycle and passes it
+// to the plugins in the context of the cycle. It does not change the decisions in the cache, so
+// the cycles which do not reserve the decisions, like the ones of the debugger, have no effect
+// on the scheduling of the controller.
+func (c *Cache) Snapshot() *Snapshot {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	now := time.Now()
+	if c.generation == c.snapshotGeneration && (c.snapshotExpiry.IsZero() || now.Before(c.snapshotExpiry)) {
+		return c.snapshot
+	}
+
+	byPlacement := map[types.NamespacedName]sets.String{}
+	for placement := range c.placementObjects {
+		byPlacement[placement] = c.writtenClusters(placement)
+	}
+	expiry := time.Time{}
+	for placement, assumed := range c.assumed {
+		expiresAt := assumed.assumedAt.Add(AssumedDecisionTTL)
+		if !now.Before(expiresAt) {
+			continue
+		}
+		byPlacement[placement] = sets.NewString(assumed.clusters.UnsortedList()...)
+		if expiry.IsZero() || expiresAt.Before(expiry) {
+			expiry = expiresAt
+		}
+	}
+
+	snapshot := newSnapshot(byPlacement)
+	c.snapshot = snapshot
+	c.snapshotGeneration = c.generation
+	c.snapshotExpiry = expiry
+	return snapshot
+}
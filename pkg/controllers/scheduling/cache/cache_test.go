@@ -0,0 +1,194 @@
+package cache
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	kcache "k8s.io/client-go/tools/cache"
+	clusterapiv1beta1 "open-cluster-management.io/api/cluster/v1beta1"
+)
+
+// the testing helpers can not be used since they depend on this package
+func newDecision(namespace, name, placement string, clusters ...string) *clusterapiv1beta1.PlacementDecision {
+	decision := &clusterapiv1beta1.PlacementDecision{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: namespace,
+			Name:      name,
+			Labels:    map[string]string{placementLabel: placement},
+		},
+	}
+	for _, cluster := range clusters {
+		decision.Status.Decisions = append(decision.Status.Decisions, clusterapiv1beta1.ClusterDecision{ClusterName: cluster})
+	}
+	return decision
+}
+
+func newPlacement(namespace, name string) *clusterapiv1beta1.Placement {
+	return &clusterapiv1beta1.Placement{ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name}}
+}
+
+func TestCache(t *testing.T) {
+	cases := []struct {
+		name               string
+		run                func(c *Cache)
+		expectedPlacements map[string][]string
+		expectedCounts     map[string]int
+	}{
+		{
+			name: "empty",
+			run:  func(c *Cache) {},
+		},
+		{
+			name: "decisions of multiple placementdecisions",
+			run: func(c *Cache) {
+				c.OnAdd(newDecision("ns1", "placement1-decision-1", "placement1", "cluster1"))
+				c.OnAdd(newDecision("ns1", "placement1-decision-2", "placement1", "cluster2"))
+				c.OnAdd(newDecision("ns2", "placement1-decision-1", "placement1", "cluster1"))
+			},
+			expectedPlacements: map[string][]string{
+				"ns1/placement1": {"cluster1", "cluster2"},
+				"ns2/placement1": {"cluster1"},
+			},
+			expectedCounts: map[string]int{"cluster1": 2, "cluster2": 1},
+		},
+		{
+			name: "update and delete",
+			run: func(c *Cache) {
+				c.OnAdd(newDecision("ns1", "placement1-decision-1", "placement1", "cluster1"))
+				c.OnAdd(newDecision("ns1", "placement2-decision-1", "placement2", "cluster1"))
+				c.OnUpdate(nil, newDecision("ns1", "placement1-decision-1", "placement1", "cluster2"))
+				c.OnDelete(kcache.DeletedFinalStateUnknown{
+					Key: "ns1/placement2-decision-1",
+					Obj: newDecision("ns1", "placement2-decision-1", "placement2", "cluster1"),
+				})
+			},
+			expectedPlacements: map[string][]string{
+				"ns1/placement1": {"cluster2"},
+			},
+			expectedCounts: map[string]int{"cluster2": 1},
+		},
+		{
+			name: "assumed decisions replace the written ones",
+			run: func(c *Cache) {
+				c.OnAdd(newDecision("ns1", "placement1-decision-1", "placement1", "cluster1"))
+				c.Assume(newPlacement("ns1", "placement1"),
+					[]clusterapiv1beta1.ClusterDecision{{ClusterName: "cluster2"}})
+				c.Assume(newPlacement("ns1", "placement2"),
+					[]clusterapiv1beta1.ClusterDecision{{ClusterName: "cluster1"}, {ClusterName: "cluster2"}})
+			},
+			expectedPlacements: map[string][]string{
+				"ns1/placement1": {"cluster2"},
+				"ns1/placement2": {"cluster1", "cluster2"},
+			},
+			expectedCounts: map[string]int{"cluster1": 1, "cluster2": 2},
+		},
+		{
+			name: "forget assumed decisions",
+			run: func(c *Cache) {
+				c.OnAdd(newDecision("ns1", "placement1-decision-1", "placement1", "cluster1"))
+				c.Assume(newPlacement("ns1", "placement1"),
+					[]clusterapiv1beta1.ClusterDecision{{ClusterName: "cluster2"}})
+				c.Forget(newPlacement("ns1", "placement1"))
+			},
+			expectedPlacements: map[string][]string{
+				"ns1/placement1": {"cluster1"},
+			},
+			expectedCounts: map[string]int{"cluster1": 1},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			cache := NewCache()
+			c.run(cache)
+			snapshot := cache.Snapshot()
+
+			actualPlacements := map[string][]string{}
+			for placement, clusters := range snapshot.byPlacement {
+				actualPlacements[placement.String()] = clusters.List()
+			}
+			if len(c.expectedPlacements) == 0 {
+				c.expectedPlacements = map[string][]string{}
+			}
+			if !reflect.DeepEqual(actualPlacements, c.expectedPlacements) {
+				t.Errorf("expected placements %v, but got %v", c.expectedPlacements, actualPlacements)
+			}
+
+			if len(c.expectedCounts) == 0 {
+				c.expectedCounts = map[string]int{}
+			}
+			if actual := snapshot.DecisionCounts(); !reflect.DeepEqual(actual, c.expectedCounts) {
+				t.Errorf("expected decision counts %v, but got %v", c.expectedCounts, actual)
+			}
+		})
+	}
+}
+
+func TestAssumedDecisions(t *testing.T) {
+	placement := newPlacement("ns1", "placement1")
+	key := types.NamespacedName{Namespace: "ns1", Name: "placement1"}
+
+	cache := NewCache()
+	cache.OnAdd(newDecision("ns1", "placement1-decision-1", "placement1", "cluster1"))
+
+	// the assumed decisions are dropped once they are written
+	cache.Assume(placement, []clusterapiv1beta1.ClusterDecision{{ClusterName: "cluster1"}, {ClusterName: "cluster2"}})
+	cache.OnAdd(newDecision("ns1", "placement1-decision-2", "placement1", "cluster2"))
+	if _, ok := cache.assumed[key]; ok {
+		t.Errorf("expected the assumed decisions to be confirmed")
+	}
+
+	// the assumed decisions expire, the snapshot does not drop them but has the written decisions
+	cache.Assume(placement, []clusterapiv1beta1.ClusterDecision{{ClusterName: "cluster3"}})
+	cache.assumed[key].assumedAt = time.Now().Add(-2 * AssumedDecisionTTL)
+	if actual := cache.Snapshot().PlacementClusters("ns1", "placement1").List(); !reflect.DeepEqual(actual, []string{"cluster1", "cluster2"}) {
+		t.Errorf("expected the written decisions, but got %v", actual)
+	}
+	if _, ok := cache.assumed[key]; !ok {
+		t.Errorf("expected the assumed decisions not to be changed by the snapshot")
+	}
+
+	// the expired assumed decisions are dropped by the next Assume
+	cache.Assume(newPlacement("ns1", "placement2"), []clusterapiv1beta1.ClusterDecision{{ClusterName: "cluster1"}})
+	if _, ok := cache.assumed[key]; ok {
+		t.Errorf("expected the assumed decisions to expire")
+	}
+}
+
+func TestSnapshot(t *testing.T) {
+	cache := NewCache()
+	cache.OnAdd(newDecision("ns2", "placement1-decision-1", "placement1", "cluster1"))
+	cache.OnAdd(newDecision("ns1", "placement2-decision-1", "placement2", "cluster1"))
+	cache.OnAdd(newDecision("ns1", "placement1-decision-1", "placement1", "cluster1", "cluster2"))
+	snapshot := cache.Snapshot()
+
+	// the snapshot is reused if the cache is not changed
+	if cache.Snapshot() != snapshot {
+		t.Errorf("expected the snapshot to be reused")
+	}
+
+	expected := []types.NamespacedName{
+		{Namespace: "ns1", Name: "placement1"},
+		{Namespace: "ns1", Name: "placement2"},
+		{Namespace: "ns2", Name: "placement1"},
+	}
+	if actual := snapshot.ClusterPlacements("cluster1"); !reflect.DeepEqual(actual, expected) {
+		t.Errorf("expected placements %v, but got %v", expected, actual)
+	}
+	if actual := snapshot.DecisionCount("cluster2"); actual != 1 {
+		t.Errorf("expected 1 decision on cluster2, but got %d", actual)
+	}
+
+	// the snapshot is not changed by the later events or the caller
+	cache.OnDelete(newDecision("ns1", "placement1-decision-1", "placement1", "cluster1", "cluster2"))
+	snapshot.PlacementClusters("ns1", "placement1").Insert("cluster3")
+	if actual := snapshot.PlacementClusters("ns1", "placement1").List(); !reflect.DeepEqual(actual, []string{"cluster1", "cluster2"}) {
+		t.Errorf("expected the snapshot to be immutable, but got %v", actual)
+	}
+	if actual := cache.Snapshot().PlacementClusters("ns1", "placement1"); actual.Len() != 0 {
+		t.Errorf("expected no decision in the new snapshot, but got %v", actual.List())
+	}
+}
@@ -0,0 +1,74 @@
+package cache
+
+import (
+	"context"
+	"sort"
+
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+// Snapshot is an immutable view of the decisions of all the placements, including the assumed
+// ones. The returned sets, slices and maps are copies which can be modified by the caller.
+type Snapshot struct {
+	// byPlacement contains the selected clusters of each placement.
+	byPlacement map[types.NamespacedName]sets.String
+	// byCluster contains the placements selecting each cluster, sorted by namespace and name.
+	byCluster map[string][]types.NamespacedName
+}
+
+func newSnapshot(byPlacement map[types.NamespacedName]sets.String) *Snapshot {
+	byCluster := map[string][]types.NamespacedName{}
+	for placement, clusters := range byPlacement {
+		for cluster := range clusters {
+			byCluster[cluster] = append(byCluster[cluster], placement)
+		}
+	}
+	for _, placements := range byCluster {
+		sort.Slice(placements, func(i, j int) bool {
+			return placements[i].String() < placements[j].String()
+		})
+	}
+
+	return &Snapshot{
+		byPlacement: byPlacement,
+		byCluster:   byCluster,
+	}
+}
+
+// PlacementClusters returns the clusters selected by the placement.
+func (s *Snapshot) PlacementClusters(namespace, name string) sets.String {
+	return sets.NewString(s.byPlacement[types.NamespacedName{Namespace: namespace, Name: name}].UnsortedList()...)
+}
+
+// ClusterPlacements returns the placements selecting the cluster, sorted by namespace and name.
+func (s *Snapshot) ClusterPlacements(clusterName string) []types.NamespacedName {
+	return append([]types.NamespacedName{}, s.byCluster[clusterName]...)
+}
+
+// DecisionCount returns the number of placements selecting the cluster.
+func (s *Snapshot) DecisionCount(clusterName string) int {
+	return len(s.byCluster[clusterName])
+}
+
+// DecisionCounts returns the number of placements selecting each cluster.
+func (s *Snapshot) DecisionCounts() map[string]int {
+	counts := make(map[string]int, len(s.byCluster))
+	for cluster, placements := range s.byCluster {
+		counts[cluster] = len(placements)
+	}
+	return counts
+}
+
+type snapshotContextKey struct{}
+
+// WithSnapshot returns a copy of the context which carries the snapshot of a scheduling cycle.
+func WithSnapshot(ctx context.Context, snapshot *Snapshot) context.Context {
+	return context.WithValue(ctx, snapshotContextKey{}, snapshot)
+}
+
+// SnapshotFromContext returns the snapshot carried by the context, or nil if there is none.
+func SnapshotFromContext(ctx context.Context) *Snapshot {
+	snapshot, _ := ctx.Value(snapshotContextKey{}).(*Snapshot)
+	return snapshot
+}
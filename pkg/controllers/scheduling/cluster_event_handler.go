@@ -4,13 +4,22 @@ import (
 	"fmt"
 	"reflect"
 
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
 	cache "k8s.io/client-go/tools/cache"
+	"k8s.io/klog/v2"
 	clusterapiv1 "open-cluster-management.io/api/cluster/v1"
+
+	"open-cluster-management.io/placement/pkg/plugins"
 )
 
 type clusterEventHandler struct {
 	enqueuer *enqueuer
+	// fields are the fields of ManagedCluster read by the scheduler. An update of a cluster
+	// which changes none of them does not enqueue the placements, so that the heartbeats of
+	// the clusters are ignored. All the fields are compared if it is empty.
+	fields []plugins.ClusterField
 }
 
 func (h *clusterEventHandler) OnAdd(obj interface{}) {
@@ -22,20 +31,20 @@ func (h *clusterEventHandler) OnUpdate(oldObj, newObj interface{}) {
 	if !ok {
 		return
 	}
-	h.enqueuer.enqueueCluster(newObj)
 
-	if oldObj == nil {
-		return
-	}
 	oldCluster, ok := oldObj.(*clusterapiv1.ManagedCluster)
 	if !ok {
+		h.enqueuer.enqueueCluster(newObj)
 		return
 	}
 
-	// if the cluster labels changes, process the original clusterset
-	if !reflect.DeepEqual(newCluster.Labels, oldCluster.Labels) {
-		h.enqueuer.enqueueCluster(oldCluster)
+	if !h.clusterChanged(oldCluster, newCluster) {
+		klog.V(5).Infof("Skip the update of cluster %q which changes no field read by the scheduler", newCluster.Name)
+		return
 	}
+	// the placements of the original clustersets and the ones matching the cluster before the
+	// change are processed as well
+	h.enqueuer.enqueueCluster(oldCluster, newCluster)
 }
 
 func (h *clusterEventHandler) OnDelete(obj interface{}) {
@@ -48,3 +57,65 @@ func (h *clusterEventHandler) OnDelete(obj interface{}) {
 		utilruntime.HandleError(fmt.Errorf("error decoding object, invalid type"))
 	}
 }
+
+// clusterChanged returns true if any field read by the scheduler changes.
+func (h *clusterEventHandler) clusterChanged(oldCluster, newCluster *clusterapiv1.ManagedCluster) bool {
+	fields := h.fields
+	if len(fields) == 0 {
+		fields = plugins.AllClusterFields
+	}
+	for _, field := range fields {
+		if clusterFieldChanged(field, oldCluster, newCluster) {
+			return true
+		}
+	}
+	return false
+}
+
+func clusterFieldChanged(field plugins.ClusterField, oldCluster, newCluster *clusterapiv1.ManagedCluster) bool {
+	switch field {
+	case plugins.ClusterFieldLabels:
+		return !reflect.DeepEqual(oldCluster.Labels, newCluster.Labels)
+	case plugins.ClusterFieldClaims:
+		return !apiequality.Semantic.DeepEqual(oldCluster.Status.ClusterClaims, newCluster.Status.ClusterClaims)
+	case plugins.ClusterFieldTaints:
+		return !apiequality.Semantic.DeepEqual(oldCluster.Spec.Taints, newCluster.Spec.Taints)
+	case plugins.ClusterFieldResources:
+		return !apiequality.Semantic.DeepEqual(oldCluster.Status.Allocatable, newCluster.Status.Allocatable) ||
+			!apiequality.Semantic.DeepEqual(oldCluster.Status.Capacity, newCluster.Status.Capacity)
+	case plugins.ClusterFieldVersion:
+		return oldCluster.Status.Version != newCluster.Status.Version
+	case plugins.ClusterFieldConditions:
+		return !apiequality.Semantic.DeepEqual(schedulingConditions(oldCluster), schedulingConditions(newCluster))
+	case plugins.ClusterFieldHubAcceptsClient:
+		return oldCluster.Spec.HubAcceptsClient != newCluster.Spec.HubAcceptsClient
+	default:
+		// a field unknown to the handler is considered changed
+		return true
+	}
+}
+
+// schedulingConditions returns the conditions of the cluster with only the fields used for
+// scheduling, the reasons and messages updated with the heartbeats are dropped.
+func schedulingConditions(cluster *clusterapiv1.ManagedCluster) map[string]metav1.Condition {
+	conditions := map[string]metav1.Condition{}
+	for _, c := range cluster.Status.Conditions {
+		conditions[c.Type] = metav1.Condition{
+			Type:               c.Type,
+			Status:             c.Status,
+			LastTransitionTime: c.LastTransitionTime,
+		}
+	}
+	return conditions
+}
+
+// clusterFieldsOf returns the fields of ManagedCluster read by the scheduler, as well as the
+// labels which decide the clustersets of the clusters. All the fields are returned if the
+// scheduler does not declare them.
+func clusterFieldsOf(scheduler Scheduler) []plugins.ClusterField {
+	reader, ok := scheduler.(plugins.ClusterFieldsReader)
+	if !ok {
+		return plugins.AllClusterFields
+	}
+	return append([]plugins.ClusterField{plugins.ClusterFieldLabels}, reader.ClusterFields()...)
+}
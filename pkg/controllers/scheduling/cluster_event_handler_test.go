@@ -4,16 +4,20 @@ import (
 	"k8s.io/client-go/util/workqueue"
 	"strings"
 	"testing"
+	"time"
 
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/client-go/tools/cache"
 
 	clusterfake "open-cluster-management.io/api/client/cluster/clientset/versioned/fake"
+	clusterapiv1 "open-cluster-management.io/api/cluster/v1"
 	clusterapiv1beta2 "open-cluster-management.io/api/cluster/v1beta2"
 
 	testinghelpers "open-cluster-management.io/placement/pkg/helpers/testing"
+	"open-cluster-management.io/placement/pkg/plugins"
 )
 
 func TestOnClusterChange(t *testing.T) {
@@ -102,6 +106,7 @@ func TestOnClusterChange(t *testing.T) {
 				clusterInformerFactory.Cluster().V1beta2().ManagedClusterSets(),
 				clusterInformerFactory.Cluster().V1beta1().Placements(),
 				clusterInformerFactory.Cluster().V1beta2().ManagedClusterSetBindings(),
+				clusterInformerFactory.Cluster().V1beta1().PlacementDecisions(),
 			)
 			queuedKeys := sets.NewString()
 			fakeEnqueuePlacement := func(obj interface{}, queue workqueue.RateLimitingInterface) {
@@ -261,6 +266,7 @@ func TestOnClusterUpdate(t *testing.T) {
 				clusterInformerFactory.Cluster().V1beta2().ManagedClusterSets(),
 				clusterInformerFactory.Cluster().V1beta1().Placements(),
 				clusterInformerFactory.Cluster().V1beta2().ManagedClusterSetBindings(),
+				clusterInformerFactory.Cluster().V1beta1().PlacementDecisions(),
 			)
 			queuedKeys := sets.NewString()
 			fakeEnqueuePlacement := func(obj interface{}, queue workqueue.RateLimitingInterface) {
@@ -281,6 +287,99 @@ func TestOnClusterUpdate(t *testing.T) {
 	}
 }
 
+func TestOnClusterUpdateFields(t *testing.T) {
+	transitionTime := time.Now().Add(-time.Hour)
+	newCluster := func() *clusterapiv1.ManagedCluster {
+		return testinghelpers.NewManagedCluster("cluster1").WithLabel(clusterSetLabel, "clusterset1").
+			WithCondition(clusterapiv1.ManagedClusterConditionAvailable, metav1.ConditionTrue, transitionTime).Build()
+	}
+	heartbeat := newCluster()
+	heartbeat.Status.Conditions[0].Reason = "ManagedClusterAvailable"
+	heartbeat.Status.Conditions[0].Message = "Managed cluster is available"
+	unavailable := testinghelpers.NewManagedCluster("cluster1").WithLabel(clusterSetLabel, "clusterset1").
+		WithCondition(clusterapiv1.ManagedClusterConditionAvailable, metav1.ConditionUnknown, time.Now()).Build()
+	tainted := newCluster()
+	tainted.Spec.Taints = []clusterapiv1.Taint{{Key: "key", Effect: clusterapiv1.TaintEffectNoSelect}}
+	resized := newCluster()
+	resized.Status.Allocatable = clusterapiv1.ResourceList{clusterapiv1.ResourceCPU: resource.MustParse("8")}
+
+	cases := []struct {
+		name     string
+		fields   []plugins.ClusterField
+		newObj   *clusterapiv1.ManagedCluster
+		enqueued bool
+	}{
+		{
+			name:   "heartbeat",
+			newObj: heartbeat,
+		},
+		{
+			name:     "condition status changes",
+			newObj:   unavailable,
+			enqueued: true,
+		},
+		{
+			name:     "condition status changes but conditions are not read",
+			fields:   []plugins.ClusterField{plugins.ClusterFieldLabels, plugins.ClusterFieldTaints},
+			newObj:   unavailable,
+			enqueued: false,
+		},
+		{
+			name:     "taints change",
+			fields:   []plugins.ClusterField{plugins.ClusterFieldLabels, plugins.ClusterFieldTaints},
+			newObj:   tainted,
+			enqueued: true,
+		},
+		{
+			name:     "resources change",
+			fields:   []plugins.ClusterField{plugins.ClusterFieldResources},
+			newObj:   resized,
+			enqueued: true,
+		},
+		{
+			name:     "resources change but resources are not read",
+			fields:   []plugins.ClusterField{plugins.ClusterFieldLabels, plugins.ClusterFieldVersion},
+			newObj:   resized,
+			enqueued: false,
+		},
+	}
+
+	initObjs := []runtime.Object{
+		testinghelpers.NewClusterSet("clusterset1").Build(),
+		testinghelpers.NewClusterSetBinding("ns1", "clusterset1"),
+		testinghelpers.NewPlacement("ns1", "placement1").Build(),
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			clusterClient := clusterfake.NewSimpleClientset(initObjs...)
+			clusterInformerFactory := newClusterInformerFactory(clusterClient, initObjs...)
+
+			syncCtx := testinghelpers.NewFakeSyncContext(t, "fake")
+			q := newEnqueuer(
+				syncCtx.Queue(),
+				clusterInformerFactory.Cluster().V1().ManagedClusters(),
+				clusterInformerFactory.Cluster().V1beta2().ManagedClusterSets(),
+				clusterInformerFactory.Cluster().V1beta1().Placements(),
+				clusterInformerFactory.Cluster().V1beta2().ManagedClusterSetBindings(),
+				clusterInformerFactory.Cluster().V1beta1().PlacementDecisions(),
+			)
+			enqueued := false
+			q.enqueuePlacementFunc = func(obj interface{}, queue workqueue.RateLimitingInterface) {
+				enqueued = true
+			}
+			handler := &clusterEventHandler{
+				enqueuer: q,
+				fields:   c.fields,
+			}
+
+			handler.OnUpdate(newCluster(), c.newObj)
+			if enqueued != c.enqueued {
+				t.Errorf("expected enqueued %v, but got %v", c.enqueued, enqueued)
+			}
+		})
+	}
+}
+
 func TestOnClusterDelete(t *testing.T) {
 	cases := []struct {
 		name       string
@@ -361,6 +460,7 @@ func TestOnClusterDelete(t *testing.T) {
 				clusterInformerFactory.Cluster().V1beta2().ManagedClusterSets(),
 				clusterInformerFactory.Cluster().V1beta1().Placements(),
 				clusterInformerFactory.Cluster().V1beta2().ManagedClusterSetBindings(),
+				clusterInformerFactory.Cluster().V1beta1().PlacementDecisions(),
 			)
 			queuedKeys := sets.NewString()
 			fakeEnqueuePlacement := func(obj interface{}, queue workqueue.RateLimitingInterface) {
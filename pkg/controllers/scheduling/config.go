@@ -0,0 +1,356 @@
+package scheduling
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"reflect"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	clusterapiv1beta1 "open-cluster-management.io/api/cluster/v1beta1"
+	"sigs.k8s.io/yaml"
+
+	"open-cluster-management.io/placement/pkg/plugins"
+	"open-cluster-management.io/placement/pkg/plugins/celpredicate"
+	"open-cluster-management.io/placement/pkg/plugins/clusterhealth"
+	"open-cluster-management.io/placement/pkg/plugins/extender"
+	"open-cluster-management.io/placement/pkg/plugins/kubernetesversion"
+	"open-cluster-management.io/placement/pkg/plugins/predicate"
+	"open-cluster-management.io/placement/pkg/plugins/tainttoleration"
+)
+
+const (
+	// SchedulerConfigurationAPIVersion is the version of the scheduler configuration file.
+	SchedulerConfigurationAPIVersion = "config.placement.open-cluster-management.io/v1alpha1"
+	// SchedulerConfigurationKind is the kind of the scheduler configuration file.
+	SchedulerConfigurationKind = "SchedulerConfiguration"
+
+	FilterPredicate       string = "Predicate"
+	FilterTaintToleration string = "TaintToleration"
+	FilterClusterHealth   string = "ClusterHealth"
+	// FilterKubernetesVersion is a no-op unless the kubernetes-version annotation is set.
+	FilterKubernetesVersion string = "KubernetesVersion"
+	// FilterCELPredicate is a no-op unless the cel-predicates annotation is set.
+	FilterCELPredicate string = "CELPredicate"
+
+	// allPlugins disables all the default filters when it is used in Filters.Disabled.
+	allPlugins = "*"
+)
+
+// SchedulerConfiguration configures the plugins of the scheduler for the whole hub.
+type SchedulerConfiguration struct {
+	metav1.TypeMeta `json:",inline"`
+
+	// Filters enables or disables the filters. The default filters run first in their default
+	// order except the disabled ones, and then the enabled ones run in the order they are listed.
+	// Disable all default filters with "*" to reorder them.
+	// +optional
+	Filters PluginSet `json:"filters,omitempty"`
+
+	// Prioritizers overrides the default weights of prioritizers, which are used when the
+	// PrioritizerPolicy mode of a placement is Additive. Set weight to 0 to disable a default
+	// prioritizer.
+	// +optional
+	Prioritizers []clusterapiv1beta1.PrioritizerConfig `json:"prioritizers,omitempty"`
+
+	// ScoreNormalization configures how the scores of each prioritizer are normalized if any of
+	// them is out of the range [-100, 100]. The out-of-range scores are clamped by default.
+	// +optional
+	ScoreNormalization []ScoreNormalizationConfig `json:"scoreNormalization,omitempty"`
+
+	// PluginConfig passes arguments to plugins. Each plugin can be configured at most once.
+	// +optional
+	PluginConfig []PluginConfig `json:"pluginConfig,omitempty"`
+
+	// Parallelism is the number of workers which run the filters and prioritizers for a
+	// placement. The prioritizers run concurrently, and the clusters are split into chunks for
+	// the plugins which evaluate each cluster independently. The default is 16.
+	// +optional
+	Parallelism int32 `json:"parallelism,omitempty"`
+
+	// Plugins enables plugins at the PreFilter, PostFilter, Reserve and PostBind extension
+	// points. The enabled filters are enabled at these extension points as well if they
+	// implement them.
+	// +optional
+	Plugins []Plugin `json:"plugins,omitempty"`
+
+	// Extenders configures HTTP extenders which filter or score clusters out of tree. The
+	// extender filters run after the other filters in the order they are listed. The extender
+	// prioritizers can be referred by their names as builtIn prioritizers in placements.
+	// +optional
+	Extenders []extender.Config `json:"extenders,omitempty"`
+}
+
+// PluginSet specifies the enabled and disabled plugins.
+type PluginSet struct {
+	// +optional
+	Enabled []Plugin `json:"enabled,omitempty"`
+	// +optional
+	Disabled []Plugin `json:"disabled,omitempty"`
+}
+
+// Plugin specifies a plugin by name.
+type Plugin struct {
+	Name string `json:"name"`
+}
+
+// PluginConfig specifies the arguments passed to a plugin.
+type PluginConfig struct {
+	Name string          `json:"name"`
+	Args json.RawMessage `json:"args,omitempty"`
+}
+
+// PluginFactory builds a plugin with the plugin arguments in the scheduler configuration.
+type PluginFactory func(handle plugins.Handle, args json.RawMessage) (plugins.Plugin, error)
+
+// defaultFilters are the filters enabled by default, in the order they run. The ClusterHealth
+// filter is enabled in the configuration only on the hubs without the taint controller.
+var defaultFilters = []string{FilterPredicate, FilterTaintToleration, FilterKubernetesVersion, FilterCELPredicate}
+
+// pluginRegistry contains the factories of all the plugins which can be enabled in the
+// scheduler configuration.
+var pluginRegistry = map[string]PluginFactory{
+	FilterPredicate: func(handle plugins.Handle, args json.RawMessage) (plugins.Plugin, error) {
+		return predicate.New(handle), decodeArgs(args, nil)
+	},
+	FilterTaintToleration: func(handle plugins.Handle, args json.RawMessage) (plugins.Plugin, error) {
+		return tainttoleration.New(handle), decodeArgs(args, nil)
+	},
+	FilterKubernetesVersion: func(handle plugins.Handle, args json.RawMessage) (plugins.Plugin, error) {
+		return kubernetesversion.New(handle), decodeArgs(args, nil)
+	},
+	FilterCELPredicate: func(handle plugins.Handle, args json.RawMessage) (plugins.Plugin, error) {
+		celArgs := &celpredicate.Args{}
+		if err := decodeArgs(args, celArgs); err != nil {
+			return nil, err
+		}
+		return celpredicate.New(handle, celArgs)
+	},
+	FilterClusterHealth: func(handle plugins.Handle, args json.RawMessage) (plugins.Plugin, error) {
+		healthArgs := &clusterhealth.Args{}
+		if err := decodeArgs(args, healthArgs); err != nil {
+			return nil, err
+		}
+		return clusterhealth.New(handle, healthArgs)
+	},
+}
+
+// RegisterPlugin registers an out-of-tree plugin, so that it can be enabled in the scheduler
+// configuration. It should be called before the scheduler is built.
+func RegisterPlugin(name string, factory PluginFactory) error {
+	if _, ok := pluginRegistry[name]; ok {
+		return fmt.Errorf("plugin %s is already registered", name)
+	}
+	pluginRegistry[name] = factory
+	return nil
+}
+
+// NewDefaultSchedulerConfiguration returns the scheduler configuration used when no
+// configuration file is specified.
+func NewDefaultSchedulerConfiguration() *SchedulerConfiguration {
+	return &SchedulerConfiguration{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: SchedulerConfigurationAPIVersion,
+			Kind:       SchedulerConfigurationKind,
+		},
+	}
+}
+
+// LoadSchedulerConfiguration reads the scheduler configuration from a YAML or JSON file and
+// validates it.
+func LoadSchedulerConfiguration(path string) (*SchedulerConfiguration, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read scheduler configuration %q: %v", path, err)
+	}
+
+	jsonData, err := yaml.YAMLToJSON(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse scheduler configuration %q: %v", path, err)
+	}
+
+	config := &SchedulerConfiguration{}
+	decoder := json.NewDecoder(bytes.NewReader(jsonData))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(config); err != nil {
+		return nil, fmt.Errorf("failed to decode scheduler configuration %q: %v", path, err)
+	}
+
+	if err := ValidateSchedulerConfiguration(config); err != nil {
+		return nil, fmt.Errorf("invalid scheduler configuration %q: %v", path, err)
+	}
+	return config, nil
+}
+
+// ValidateSchedulerConfiguration validates the scheduler configuration and returns an aggregated
+// error with all the invalid fields.
+func ValidateSchedulerConfiguration(config *SchedulerConfiguration) error {
+	errs := field.ErrorList{}
+
+	if config.APIVersion != SchedulerConfigurationAPIVersion {
+		errs = append(errs, field.NotSupported(field.NewPath("apiVersion"), config.APIVersion, []string{SchedulerConfigurationAPIVersion}))
+	}
+	if config.Kind != SchedulerConfigurationKind {
+		errs = append(errs, field.NotSupported(field.NewPath("kind"), config.Kind, []string{SchedulerConfigurationKind}))
+	}
+
+	if config.Parallelism < 0 {
+		errs = append(errs, field.Invalid(field.NewPath("parallelism"), config.Parallelism, "must not be negative"))
+	}
+
+	knownPlugins := sets.StringKeySet(pluginRegistry).List()
+	enabled := sets.NewString()
+	for i, p := range config.Filters.Enabled {
+		path := field.NewPath("filters", "enabled").Index(i).Child("name")
+		switch {
+		case pluginRegistry[p.Name] == nil:
+			errs = append(errs, field.NotSupported(path, p.Name, knownPlugins))
+		case enabled.Has(p.Name):
+			errs = append(errs, field.Duplicate(path, p.Name))
+		}
+		enabled.Insert(p.Name)
+	}
+	for i, p := range config.Filters.Disabled {
+		path := field.NewPath("filters", "disabled").Index(i).Child("name")
+		if p.Name != allPlugins && pluginRegistry[p.Name] == nil {
+			errs = append(errs, field.NotSupported(path, p.Name, append([]string{allPlugins}, knownPlugins...)))
+		}
+	}
+	enabledPlugins := sets.NewString()
+	for i, p := range config.Plugins {
+		path := field.NewPath("plugins").Index(i).Child("name")
+		switch {
+		case pluginRegistry[p.Name] == nil:
+			errs = append(errs, field.NotSupported(path, p.Name, knownPlugins))
+		case enabledPlugins.Has(p.Name):
+			errs = append(errs, field.Duplicate(path, p.Name))
+		}
+		enabledPlugins.Insert(p.Name)
+	}
+
+	extenderNames := sets.NewString()
+	extenderPrioritizers := map[string]plugins.Prioritizer{}
+	for i, e := range config.Extenders {
+		path := field.NewPath("extenders").Index(i)
+		errs = append(errs, extender.ValidateConfig(e, path)...)
+		_, status := getPrioritizers(map[clusterapiv1beta1.ScoreCoordinate]int32{
+			{Type: clusterapiv1beta1.ScoreCoordinateTypeBuiltIn, BuiltIn: e.Name}: 1,
+		}, nil, nil)
+		switch {
+		case pluginRegistry[e.Name] != nil || status.IsSuccess():
+			errs = append(errs, field.Invalid(path.Child("name"), e.Name, "conflicts with an in-tree plugin"))
+		case extenderNames.Has(e.Name):
+			errs = append(errs, field.Duplicate(path.Child("name"), e.Name))
+		}
+		extenderNames.Insert(e.Name)
+		if len(e.PrioritizeVerb) > 0 {
+			// the extender is registered to validate the prioritizer names only
+			extenderPrioritizers[e.Name] = &extender.Extender{}
+		}
+	}
+
+	if _, status := mergeWeights(nil, config.Prioritizers); status.IsError() {
+		errs = append(errs, field.Required(field.NewPath("prioritizers"), status.Message()))
+	}
+	for i, p := range config.Prioritizers {
+		path := field.NewPath("prioritizers").Index(i)
+		if p.Weight < -10 || p.Weight > 10 {
+			errs = append(errs, field.Invalid(path.Child("weight"), p.Weight, "must be in the range [-10, 10]"))
+		}
+		if p.ScoreCoordinate == nil {
+			continue
+		}
+		if _, status := getPrioritizers(map[clusterapiv1beta1.ScoreCoordinate]int32{*p.ScoreCoordinate: 1}, nil, extenderPrioritizers); status.IsError() {
+			errs = append(errs, field.Invalid(path.Child("scoreCoordinate"), *p.ScoreCoordinate, status.Message()))
+		}
+	}
+
+	for i, n := range config.ScoreNormalization {
+		path := field.NewPath("scoreNormalization").Index(i)
+		switch n.Mode {
+		case ScoreNormalizationClamp, ScoreNormalizationMinMax, ScoreNormalizationRank:
+		default:
+			errs = append(errs, field.NotSupported(path.Child("mode"), n.Mode, []string{
+				string(ScoreNormalizationClamp), string(ScoreNormalizationMinMax), string(ScoreNormalizationRank)}))
+		}
+		if _, status := getPrioritizers(map[clusterapiv1beta1.ScoreCoordinate]int32{n.ScoreCoordinate: 1}, nil, extenderPrioritizers); status.IsError() {
+			errs = append(errs, field.Invalid(path.Child("scoreCoordinate"), n.ScoreCoordinate, status.Message()))
+		}
+		for _, prev := range config.ScoreNormalization[:i] {
+			if reflect.DeepEqual(prev.ScoreCoordinate, n.ScoreCoordinate) {
+				errs = append(errs, field.Duplicate(path.Child("scoreCoordinate"), n.ScoreCoordinate))
+				break
+			}
+		}
+	}
+
+	configured := sets.NewString()
+	for i, c := range config.PluginConfig {
+		path := field.NewPath("pluginConfig").Index(i).Child("name")
+		switch {
+		case pluginRegistry[c.Name] == nil:
+			errs = append(errs, field.NotSupported(path, c.Name, knownPlugins))
+		case configured.Has(c.Name):
+			errs = append(errs, field.Duplicate(path, c.Name))
+		default:
+			// build the plugin to validate the arguments
+			if _, err := pluginRegistry[c.Name](nil, c.Args); err != nil {
+				errs = append(errs, field.Invalid(field.NewPath("pluginConfig").Index(i).Child("args"), string(c.Args), err.Error()))
+			}
+		}
+		configured.Insert(c.Name)
+	}
+
+	return errs.ToAggregate()
+}
+
+// filterNames returns the names of the enabled filters in the order they run.
+func (c *SchedulerConfiguration) filterNames() []string {
+	disabled := sets.NewString()
+	for _, p := range c.Filters.Disabled {
+		disabled.Insert(p.Name)
+	}
+
+	names := []string{}
+	if !disabled.Has(allPlugins) {
+		for _, name := range defaultFilters {
+			if !disabled.Has(name) {
+				names = append(names, name)
+			}
+		}
+	}
+	for _, p := range c.Filters.Enabled {
+		if !sets.NewString(names...).Has(p.Name) {
+			names = append(names, p.Name)
+		}
+	}
+	return names
+}
+
+// pluginArgs returns the arguments of the plugin in the configuration.
+func (c *SchedulerConfiguration) pluginArgs(name string) json.RawMessage {
+	for _, pc := range c.PluginConfig {
+		if pc.Name == name {
+			return pc.Args
+		}
+	}
+	return nil
+}
+
+// decodeArgs strictly decodes the plugin arguments into the given object. Arguments are not
+// allowed if obj is nil.
+func decodeArgs(args json.RawMessage, obj interface{}) error {
+	if len(args) == 0 || string(args) == "null" {
+		return nil
+	}
+	if obj == nil {
+		return fmt.Errorf("the plugin does not accept arguments")
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(args))
+	decoder.DisallowUnknownFields()
+	return decoder.Decode(obj)
+}
@@ -0,0 +1,265 @@
+package scheduling
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	clusterfake "open-cluster-management.io/api/client/cluster/clientset/versioned/fake"
+	clusterapiv1beta1 "open-cluster-management.io/api/cluster/v1beta1"
+	testinghelpers "open-cluster-management.io/placement/pkg/helpers/testing"
+)
+
+func TestLoadSchedulerConfiguration(t *testing.T) {
+	cases := []struct {
+		name            string
+		config          string
+		expectedErr     string
+		expectedFilters []string
+		expectedWeights map[clusterapiv1beta1.ScoreCoordinate]int32
+	}{
+		{
+			name: "empty configuration",
+			config: `
+apiVersion: config.placement.open-cluster-management.io/v1alpha1
+kind: SchedulerConfiguration
+`,
+			expectedFilters: []string{"Predicate", "TaintToleration", "KubernetesVersion", "CELPredicate"},
+			expectedWeights: map[clusterapiv1beta1.ScoreCoordinate]int32{
+				{Type: clusterapiv1beta1.ScoreCoordinateTypeBuiltIn, BuiltIn: PrioritizerBalance}:         1,
+				{Type: clusterapiv1beta1.ScoreCoordinateTypeBuiltIn, BuiltIn: PrioritizerSteady}:          1,
+				{Type: clusterapiv1beta1.ScoreCoordinateTypeBuiltIn, BuiltIn: PrioritizerTaintToleration}: 1,
+			},
+		},
+		{
+			name: "reorder filters and override weights",
+			config: `
+apiVersion: config.placement.open-cluster-management.io/v1alpha1
+kind: SchedulerConfiguration
+filters:
+  disabled:
+  - name: "*"
+  enabled:
+  - name: TaintToleration
+  - name: Predicate
+prioritizers:
+- scoreCoordinate:
+    type: BuiltIn
+    builtIn: Balance
+  weight: 0
+- scoreCoordinate:
+    type: BuiltIn
+    builtIn: ResourceAllocatableCPU
+  weight: 2
+scoreNormalization:
+- scoreCoordinate:
+    type: AddOn
+    addOn:
+      resourceName: default
+      scoreName: cpuratio
+  mode: MinMax
+`,
+			expectedFilters: []string{"TaintToleration", "Predicate"},
+			expectedWeights: map[clusterapiv1beta1.ScoreCoordinate]int32{
+				{Type: clusterapiv1beta1.ScoreCoordinateTypeBuiltIn, BuiltIn: PrioritizerBalance}:                0,
+				{Type: clusterapiv1beta1.ScoreCoordinateTypeBuiltIn, BuiltIn: PrioritizerSteady}:                 1,
+				{Type: clusterapiv1beta1.ScoreCoordinateTypeBuiltIn, BuiltIn: PrioritizerTaintToleration}:        1,
+				{Type: clusterapiv1beta1.ScoreCoordinateTypeBuiltIn, BuiltIn: PrioritizerResourceAllocatableCPU}: 2,
+			},
+		},
+		{
+			name: "disable a filter",
+			config: `
+apiVersion: config.placement.open-cluster-management.io/v1alpha1
+kind: SchedulerConfiguration
+filters:
+  disabled:
+  - name: TaintToleration
+`,
+			expectedFilters: []string{"Predicate", "KubernetesVersion", "CELPredicate"},
+			expectedWeights: map[clusterapiv1beta1.ScoreCoordinate]int32{
+				{Type: clusterapiv1beta1.ScoreCoordinateTypeBuiltIn, BuiltIn: PrioritizerBalance}:         1,
+				{Type: clusterapiv1beta1.ScoreCoordinateTypeBuiltIn, BuiltIn: PrioritizerSteady}:          1,
+				{Type: clusterapiv1beta1.ScoreCoordinateTypeBuiltIn, BuiltIn: PrioritizerTaintToleration}: 1,
+			},
+		},
+		{
+			name: "enable cluster health filter",
+			config: `
+apiVersion: config.placement.open-cluster-management.io/v1alpha1
+kind: SchedulerConfiguration
+filters:
+  enabled:
+  - name: ClusterHealth
+pluginConfig:
+- name: ClusterHealth
+  args:
+    gracePeriod: 5m
+`,
+			expectedFilters: []string{"Predicate", "TaintToleration", "KubernetesVersion", "CELPredicate", "ClusterHealth"},
+			expectedWeights: map[clusterapiv1beta1.ScoreCoordinate]int32{
+				{Type: clusterapiv1beta1.ScoreCoordinateTypeBuiltIn, BuiltIn: PrioritizerBalance}:         1,
+				{Type: clusterapiv1beta1.ScoreCoordinateTypeBuiltIn, BuiltIn: PrioritizerSteady}:          1,
+				{Type: clusterapiv1beta1.ScoreCoordinateTypeBuiltIn, BuiltIn: PrioritizerTaintToleration}: 1,
+			},
+		},
+		{
+			name: "extenders",
+			config: `
+apiVersion: config.placement.open-cluster-management.io/v1alpha1
+kind: SchedulerConfiguration
+extenders:
+- name: Compliance
+  urlPrefix: https://compliance.example.com/placement
+  filterVerb: filter
+  httpTimeout: 3s
+  ignorable: true
+- name: Licensing
+  urlPrefix: https://licensing.example.com/placement
+  prioritizeVerb: prioritize
+  weight: 2
+  tlsConfig:
+    insecure: true
+`,
+			expectedFilters: []string{"Predicate", "TaintToleration", "KubernetesVersion", "CELPredicate", "Compliance"},
+			expectedWeights: map[clusterapiv1beta1.ScoreCoordinate]int32{
+				{Type: clusterapiv1beta1.ScoreCoordinateTypeBuiltIn, BuiltIn: PrioritizerBalance}:         1,
+				{Type: clusterapiv1beta1.ScoreCoordinateTypeBuiltIn, BuiltIn: PrioritizerSteady}:          1,
+				{Type: clusterapiv1beta1.ScoreCoordinateTypeBuiltIn, BuiltIn: PrioritizerTaintToleration}: 1,
+				{Type: clusterapiv1beta1.ScoreCoordinateTypeBuiltIn, BuiltIn: "Licensing"}:                2,
+			},
+		},
+		{
+			name: "invalid extenders",
+			config: `
+apiVersion: config.placement.open-cluster-management.io/v1alpha1
+kind: SchedulerConfiguration
+extenders:
+- name: Steady
+  urlPrefix: https://steady.example.com
+  prioritizeVerb: prioritize
+- name: Compliance
+  urlPrefix: https://compliance.example.com/placement
+  filterVerb: filter
+prioritizers:
+- scoreCoordinate:
+    type: BuiltIn
+    builtIn: Compliance
+  weight: 1
+`,
+			expectedErr: strings.Join([]string{
+				`extenders[0].name: Invalid value: "Steady": conflicts with an in-tree plugin`,
+				`prioritizers[0].scoreCoordinate: Invalid value`,
+			}, "|"),
+		},
+		{
+			name: "wrong kind",
+			config: `
+apiVersion: config.placement.open-cluster-management.io/v1alpha1
+kind: Placement
+`,
+			expectedErr: `kind: Unsupported value: "Placement"`,
+		},
+		{
+			name: "unknown field",
+			config: `
+apiVersion: config.placement.open-cluster-management.io/v1alpha1
+kind: SchedulerConfiguration
+filter: {}
+`,
+			expectedErr: `unknown field "filter"`,
+		},
+		{
+			name: "invalid plugins",
+			config: `
+apiVersion: config.placement.open-cluster-management.io/v1alpha1
+kind: SchedulerConfiguration
+parallelism: -1
+filters:
+  enabled:
+  - name: Unknown
+  disabled:
+  - name: Predicate
+  - name: Predicate2
+prioritizers:
+- scoreCoordinate:
+    type: BuiltIn
+    builtIn: Unknown
+  weight: 11
+plugins:
+- name: TaintToleration
+- name: TaintToleration
+scoreNormalization:
+- scoreCoordinate:
+    type: BuiltIn
+    builtIn: Steady
+  mode: Rank
+- scoreCoordinate:
+    type: BuiltIn
+    builtIn: Steady
+  mode: Scale
+pluginConfig:
+- name: Predicate
+  args:
+    key: value
+- name: ClusterHealth
+  args:
+    gracePeriod: -1m
+`,
+			expectedErr: strings.Join([]string{
+				`parallelism: Invalid value: -1`,
+				`filters.enabled[0].name: Unsupported value: "Unknown"`,
+				`filters.disabled[1].name: Unsupported value: "Predicate2"`,
+				`plugins[1].name: Duplicate value: "TaintToleration"`,
+				`prioritizers[0].weight: Invalid value: 11`,
+				`prioritizers[0].scoreCoordinate: Invalid value`,
+				`scoreNormalization[1].mode: Unsupported value: "Scale"`,
+				`scoreNormalization[1].scoreCoordinate: Duplicate value`,
+				`pluginConfig[0].args: Invalid value: "{\"key\":\"value\"}": the plugin does not accept arguments`,
+				`pluginConfig[1].args: Invalid value: "{\"gracePeriod\":\"-1m\"}": gracePeriod must not be negative`,
+			}, "|"),
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "config.yaml")
+			if err := os.WriteFile(path, []byte(c.config), 0600); err != nil {
+				t.Fatalf("unexpected err: %v", err)
+			}
+
+			config, err := LoadSchedulerConfiguration(path)
+			if len(c.expectedErr) > 0 {
+				if err == nil {
+					t.Fatalf("expected error %q, but got nil", c.expectedErr)
+				}
+				for _, expected := range strings.Split(c.expectedErr, "|") {
+					if !strings.Contains(err.Error(), expected) {
+						t.Errorf("expected error containing %q, but got %q", expected, err.Error())
+					}
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected err: %v", err)
+			}
+
+			s, err := NewPluginSchedulerWithConfig(testinghelpers.NewFakePluginHandle(t, clusterfake.NewSimpleClientset()), config)
+			if err != nil {
+				t.Fatalf("unexpected err: %v", err)
+			}
+			filters := []string{}
+			for _, f := range s.filters {
+				filters = append(filters, f.Name())
+			}
+			if !reflect.DeepEqual(filters, c.expectedFilters) {
+				t.Errorf("expected filters %v, but got %v", c.expectedFilters, filters)
+			}
+			if !reflect.DeepEqual(s.prioritizerWeights, c.expectedWeights) {
+				t.Errorf("expected weights %v, but got %v", c.expectedWeights, s.prioritizerWeights)
+			}
+		})
+	}
+}
@@ -0,0 +1,143 @@
+package scheduling
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	clusterapiv1beta1 "open-cluster-management.io/api/cluster/v1beta1"
+)
+
+// decisionShard is the cluster decisions in the placementdecision <placement>-decision-<index>.
+type decisionShard struct {
+	index     int
+	decisions []clusterapiv1beta1.ClusterDecision
+}
+
+func placementDecisionName(placementName string, index int) string {
+	return fmt.Sprintf("%s-decision-%d", placementName, index)
+}
+
+// placementDecisionIndex returns the index in the name of a placementdecision of the placement.
+func placementDecisionIndex(placementName, placementDecisionName string) (int, bool) {
+	suffix := strings.TrimPrefix(placementDecisionName, placementName+"-decision-")
+	if suffix == placementDecisionName {
+		return 0, false
+	}
+	index, err := strconv.Atoi(suffix)
+	if err != nil || index < 1 {
+		return 0, false
+	}
+	return index, true
+}
+
+// shardDecisions splits the cluster decisions into the placementdecisions of a placement with at
+// most shardSize decisions in each, so that as few placementdecisions as possible are rewritten.
+// A cluster stays in the placementdecision it is already in, and the new clusters fill the gaps
+// of the existing placementdecisions before new placementdecisions are added. The decisions are
+// re-sharded by cluster name only if the placementdecisions are fragmented, which means more
+// placementdecisions than needed are less than half full on average.
+func shardDecisions(
+	placementName string,
+	clusterDecisions []clusterapiv1beta1.ClusterDecision,
+	placementDecisions []*clusterapiv1beta1.PlacementDecision,
+	shardSize int,
+) []decisionShard {
+	sorted := make([]clusterapiv1beta1.ClusterDecision, len(clusterDecisions))
+	copy(sorted, clusterDecisions)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].ClusterName < sorted[j].ClusterName
+	})
+
+	// the index of the placementdecision each cluster is in
+	existingIndices := []int{}
+	clusterIndices := map[string]int{}
+	for _, pd := range placementDecisions {
+		index, ok := placementDecisionIndex(placementName, pd.Name)
+		if !ok {
+			continue
+		}
+		existingIndices = append(existingIndices, index)
+		for _, decision := range pd.Status.Decisions {
+			clusterIndices[decision.ClusterName] = index
+		}
+	}
+	sort.Ints(existingIndices)
+
+	// keep one placementdecision with empty decisions if no cluster is selected
+	if len(sorted) == 0 {
+		index := 1
+		if len(existingIndices) > 0 {
+			index = existingIndices[0]
+		}
+		return []decisionShard{{index: index, decisions: []clusterapiv1beta1.ClusterDecision{}}}
+	}
+
+	shards := map[int][]clusterapiv1beta1.ClusterDecision{}
+	newDecisions := []clusterapiv1beta1.ClusterDecision{}
+	for _, decision := range sorted {
+		index, ok := clusterIndices[decision.ClusterName]
+		if ok && len(shards[index]) < shardSize {
+			shards[index] = append(shards[index], decision)
+			continue
+		}
+		newDecisions = append(newDecisions, decision)
+	}
+
+	// fill the gaps of the existing placementdecisions, and then add placementdecisions with
+	// the lowest free indices
+	existing := map[int]bool{}
+	for _, index := range existingIndices {
+		existing[index] = true
+		for len(newDecisions) > 0 && len(shards[index]) < shardSize {
+			shards[index] = append(shards[index], newDecisions[0])
+			newDecisions = newDecisions[1:]
+		}
+	}
+	for index := 1; len(newDecisions) > 0; index++ {
+		if existing[index] {
+			continue
+		}
+		size := shardSize
+		if len(newDecisions) < size {
+			size = len(newDecisions)
+		}
+		shards[index] = newDecisions[:size]
+		newDecisions = newDecisions[size:]
+	}
+
+	result := []decisionShard{}
+	for index, decisions := range shards {
+		if len(decisions) == 0 {
+			continue
+		}
+		sort.SliceStable(decisions, func(i, j int) bool {
+			return decisions[i].ClusterName < decisions[j].ClusterName
+		})
+		result = append(result, decisionShard{index: index, decisions: decisions})
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].index < result[j].index
+	})
+
+	minShards := (len(sorted) + shardSize - 1) / shardSize
+	if len(result) > minShards && 2*len(sorted) < len(result)*shardSize {
+		return rebalanceDecisions(sorted, shardSize)
+	}
+	return result
+}
+
+// rebalanceDecisions splits the decisions sorted by cluster name into the fewest placementdecisions.
+func rebalanceDecisions(sorted []clusterapiv1beta1.ClusterDecision, shardSize int) []decisionShard {
+	result := []decisionShard{}
+	for index := 1; len(sorted) > 0; index++ {
+		size := shardSize
+		if len(sorted) < size {
+			size = len(sorted)
+		}
+		result = append(result, decisionShard{index: index, decisions: sorted[:size]})
+		sorted = sorted[size:]
+	}
+	return result
+}
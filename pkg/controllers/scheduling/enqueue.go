@@ -3,6 +3,7 @@ package scheduling
 import (
 	"fmt"
 
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/runtime"
 	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/client-go/tools/cache"
@@ -16,6 +17,8 @@ import (
 	clusterapiv1 "open-cluster-management.io/api/cluster/v1"
 	clusterapiv1beta1 "open-cluster-management.io/api/cluster/v1beta1"
 	clusterapiv1beta2 "open-cluster-management.io/api/cluster/v1beta2"
+
+	"open-cluster-management.io/placement/pkg/plugins/predicate"
 )
 
 const (
@@ -25,6 +28,13 @@ const (
 	placementsByClusterSetBinding  = "placementsByClusterSet"
 	clustersetBindingsByClusterSet = "clustersetBindingsByClusterSet"
 	placementsByScore              = "placementsByScore"
+	// anyPredicateKey is an index value for indexPlacementByPredicateKey for placement
+	// whose predicates may match a cluster without any specific label or claim
+	anyPredicateKey          = "*"
+	placementsByPredicateKey = "placementsByPredicateKey"
+	// PlacementDecisionsByCluster is the name of the index of placementdecisions by the
+	// clusters in their decisions.
+	PlacementDecisionsByCluster = "placementDecisionsByCluster"
 )
 
 type enqueuer struct {
@@ -35,6 +45,7 @@ type enqueuer struct {
 	clusterSetLister         clusterlisterv1beta2.ManagedClusterSetLister
 	placementIndexer         cache.Indexer
 	clusterSetBindingIndexer cache.Indexer
+	placementDecisionIndexer cache.Indexer
 }
 
 func newEnqueuer(
@@ -42,15 +53,22 @@ func newEnqueuer(
 	clusterInformer clusterinformerv1.ManagedClusterInformer,
 	clusterSetInformer clusterinformerv1beta2.ManagedClusterSetInformer,
 	placementInformer clusterinformerv1beta1.PlacementInformer,
-	clusterSetBindingInformer clusterinformerv1beta2.ManagedClusterSetBindingInformer) *enqueuer {
+	clusterSetBindingInformer clusterinformerv1beta2.ManagedClusterSetBindingInformer,
+	placementDecisionInformer clusterinformerv1beta1.PlacementDecisionInformer) *enqueuer {
 	err := placementInformer.Informer().AddIndexers(cache.Indexers{
 		placementsByScore:             indexPlacementsByScore,
 		placementsByClusterSetBinding: indexPlacementByClusterSetBinding,
+		placementsByPredicateKey:      indexPlacementByPredicateKey,
 	})
 	if err != nil {
 		runtime.HandleError(err)
 	}
 
+	err = AddPlacementDecisionIndexer(placementDecisionInformer)
+	if err != nil {
+		runtime.HandleError(err)
+	}
+
 	err = clusterSetBindingInformer.Informer().AddIndexers(cache.Indexers{
 		clustersetBindingsByClusterSet: indexClusterSetBindingByClusterSet,
 	})
@@ -65,9 +83,22 @@ func newEnqueuer(
 		clusterSetLister:         clusterSetInformer.Lister(),
 		placementIndexer:         placementInformer.Informer().GetIndexer(),
 		clusterSetBindingIndexer: clusterSetBindingInformer.Informer().GetIndexer(),
+		placementDecisionIndexer: placementDecisionInformer.Informer().GetIndexer(),
 	}
 }
 
+// AddPlacementDecisionIndexer adds the PlacementDecisionsByCluster index to the informer if it
+// is not added yet, so that it can be shared by the scheduling controller and the debugger.
+func AddPlacementDecisionIndexer(placementDecisionInformer clusterinformerv1beta1.PlacementDecisionInformer) error {
+	indexer := placementDecisionInformer.Informer().GetIndexer()
+	if _, ok := indexer.GetIndexers()[PlacementDecisionsByCluster]; ok {
+		return nil
+	}
+	return indexer.AddIndexers(cache.Indexers{
+		PlacementDecisionsByCluster: indexPlacementDecisionByCluster,
+	})
+}
+
 func enqueuePlacement(obj interface{}, queue workqueue.RateLimitingInterface) {
 	key, err := cache.DeletionHandlingMetaNamespaceKeyFunc(obj)
 	if err != nil {
@@ -132,23 +163,143 @@ func (e *enqueuer) enqueueClusterSet(obj interface{}) {
 	}
 }
 
-func (e *enqueuer) enqueueCluster(obj interface{}) {
-	cluster, ok := obj.(*clusterapiv1.ManagedCluster)
-	if !ok {
-		runtime.HandleError(fmt.Errorf("obj %T is not a ManagedCluster", obj))
+// enqueueCluster enqueues the placements which may be affected by the change of a cluster.
+// The objs are the versions of the cluster before and after the change.
+func (e *enqueuer) enqueueCluster(objs ...interface{}) {
+	clusters := []*clusterapiv1.ManagedCluster{}
+	for _, obj := range objs {
+		cluster, ok := obj.(*clusterapiv1.ManagedCluster)
+		if !ok {
+			runtime.HandleError(fmt.Errorf("obj %T is not a ManagedCluster", obj))
+			return
+		}
+		clusters = append(clusters, cluster)
+	}
+	if len(clusters) == 0 {
 		return
 	}
+	clusterName := clusters[0].Name
 
-	clusterSets, err := clusterapiv1beta2.GetClusterSetsOfCluster(cluster, e.clusterSetLister)
+	// the placements which selected the cluster are always enqueued
+	selected, err := e.placementsSelecting(clusterName)
 	if err != nil {
-		klog.V(4).Infof("Unable to get clusterSets of cluster %q: %w", cluster.GetName(), err)
+		runtime.HandleError(err)
+		return
+	}
+	for _, key := range selected.List() {
+		obj, exists, err := e.placementIndexer.GetByKey(key)
+		if err != nil || !exists {
+			continue
+		}
+		klog.V(4).Infof("enqueue placement %s, because cluster %s is in its decisions", key, clusterName)
+		e.enqueuePlacementFunc(obj, e.queue)
+	}
+
+	// the other placements are enqueued only if their predicates match the cluster before or
+	// after the change, and the cluster is in their clustersets.
+	candidates, err := e.placementsByPredicateKeys(clusters)
+	if err != nil {
+		runtime.HandleError(err)
 		return
 	}
+	for _, placement := range e.placementsOfClusterSets(clusters) {
+		key, _ := cache.MetaNamespaceKeyFunc(placement)
+		if selected.Has(key) || !candidates.Has(key) {
+			continue
+		}
+		for _, cluster := range clusters {
+			if predicate.MayMatch(placement, cluster) {
+				klog.V(4).Infof("enqueue placement %s, because its predicates match cluster %s", key, clusterName)
+				e.enqueuePlacementFunc(placement, e.queue)
+				break
+			}
+		}
+	}
+}
+
+// placementsSelecting returns the keys of the placements which have the cluster in their
+// decisions.
+func (e *enqueuer) placementsSelecting(clusterName string) (sets.String, error) {
+	objs, err := e.placementDecisionIndexer.ByIndex(PlacementDecisionsByCluster, clusterName)
+	if err != nil {
+		return nil, err
+	}
+
+	keys := sets.NewString()
+	for _, o := range objs {
+		placementDecision := o.(*clusterapiv1beta1.PlacementDecision)
+		placementName, ok := placementDecision.Labels[placementLabel]
+		if !ok {
+			continue
+		}
+		keys.Insert(fmt.Sprintf("%s/%s", placementDecision.Namespace, placementName))
+	}
+	return keys, nil
+}
+
+// placementsByPredicateKeys returns the keys of the placements whose predicates may match the
+// clusters, according to the label and claim keys of the clusters.
+func (e *enqueuer) placementsByPredicateKeys(clusters []*clusterapiv1.ManagedCluster) (sets.String, error) {
+	predicateKeys := sets.NewString(anyPredicateKey)
+	for _, cluster := range clusters {
+		for key := range cluster.Labels {
+			predicateKeys.Insert(labelPredicateKey(key))
+		}
+		for _, claim := range cluster.Status.ClusterClaims {
+			predicateKeys.Insert(claimPredicateKey(claim.Name))
+		}
+	}
 
-	for _, clusterSet := range clusterSets {
-		klog.V(4).Infof("enqueue clusterSet %s, because of cluster %s", clusterSet.Name, cluster.Name)
-		e.enqueueClusterSet(clusterSet)
+	keys := sets.NewString()
+	for _, predicateKey := range predicateKeys.List() {
+		objs, err := e.placementIndexer.ByIndex(placementsByPredicateKey, predicateKey)
+		if err != nil {
+			return nil, err
+		}
+		for _, o := range objs {
+			key, _ := cache.MetaNamespaceKeyFunc(o)
+			keys.Insert(key)
+		}
 	}
+	return keys, nil
+}
+
+// placementsOfClusterSets returns the placements bound to the clustersets of the clusters.
+func (e *enqueuer) placementsOfClusterSets(clusters []*clusterapiv1.ManagedCluster) map[string]*clusterapiv1beta1.Placement {
+	placements := map[string]*clusterapiv1beta1.Placement{}
+	for _, cluster := range clusters {
+		clusterSets, err := clusterapiv1beta2.GetClusterSetsOfCluster(cluster, e.clusterSetLister)
+		if err != nil {
+			klog.V(4).Infof("Unable to get clusterSets of cluster %q: %w", cluster.GetName(), err)
+			continue
+		}
+
+		for _, clusterSet := range clusterSets {
+			bindingObjs, err := e.clusterSetBindingIndexer.ByIndex(clustersetBindingsByClusterSet, clusterSet.Name)
+			if err != nil {
+				runtime.HandleError(err)
+				continue
+			}
+			for _, bindingObj := range bindingObjs {
+				binding := bindingObj.(*clusterapiv1beta2.ManagedClusterSetBinding)
+				for _, indexValue := range []string{
+					fmt.Sprintf("%s/%s", binding.Namespace, clusterSet.Name),
+					fmt.Sprintf("%s/%s", binding.Namespace, anyClusterSet),
+				} {
+					objs, err := e.placementIndexer.ByIndex(placementsByClusterSetBinding, indexValue)
+					if err != nil {
+						runtime.HandleError(err)
+						continue
+					}
+					for _, o := range objs {
+						placement := o.(*clusterapiv1beta1.Placement)
+						placements[placement.Namespace+"/"+placement.Name] = placement
+					}
+				}
+			}
+		}
+	}
+	return placements
 }
 
 func (e *enqueuer) enqueuePlacementScore(obj interface{}) {
@@ -226,6 +377,78 @@ func indexPlacementByClusterSetBinding(obj interface{}) ([]string, error) {
 	return bindings, nil
 }
 
+// indexPlacementByPredicateKey indexes a placement by the label and claim keys a cluster must
+// have to match its predicates. A placement is indexed by anyPredicateKey if any of its
+// predicates may match a cluster without a specific key, such as an empty predicate or one with
+// only NotIn and DoesNotExist requirements.
+func indexPlacementByPredicateKey(obj interface{}) ([]string, error) {
+	placement, ok := obj.(*clusterapiv1beta1.Placement)
+	if !ok {
+		return []string{}, fmt.Errorf("obj %T is not a Placement", obj)
+	}
+
+	if len(placement.Spec.Predicates) == 0 {
+		return []string{anyPredicateKey}, nil
+	}
+
+	keys := sets.NewString()
+	for _, predicate := range placement.Spec.Predicates {
+		requiredKeys := requiredPredicateKeys(predicate)
+		if len(requiredKeys) == 0 {
+			return []string{anyPredicateKey}, nil
+		}
+		keys.Insert(requiredKeys...)
+	}
+	return keys.List(), nil
+}
+
+// requiredPredicateKeys returns the label and claim keys a cluster must have to match the
+// predicate.
+func requiredPredicateKeys(predicate clusterapiv1beta1.ClusterPredicate) []string {
+	keys := []string{}
+	labelSelector := predicate.RequiredClusterSelector.LabelSelector
+	for key := range labelSelector.MatchLabels {
+		keys = append(keys, labelPredicateKey(key))
+	}
+	for _, expr := range labelSelector.MatchExpressions {
+		if requiresKey(expr.Operator) {
+			keys = append(keys, labelPredicateKey(expr.Key))
+		}
+	}
+	for _, expr := range predicate.RequiredClusterSelector.ClaimSelector.MatchExpressions {
+		if requiresKey(expr.Operator) {
+			keys = append(keys, claimPredicateKey(expr.Key))
+		}
+	}
+	return keys
+}
+
+func requiresKey(operator metav1.LabelSelectorOperator) bool {
+	return operator == metav1.LabelSelectorOpIn || operator == metav1.LabelSelectorOpExists
+}
+
+func labelPredicateKey(key string) string {
+	return "label/" + key
+}
+
+func claimPredicateKey(key string) string {
+	return "claim/" + key
+}
+
+// indexPlacementDecisionByCluster indexes a placementdecision by the clusters in its decisions.
+func indexPlacementDecisionByCluster(obj interface{}) ([]string, error) {
+	placementDecision, ok := obj.(*clusterapiv1beta1.PlacementDecision)
+	if !ok {
+		return []string{}, fmt.Errorf("obj %T is not a PlacementDecision", obj)
+	}
+
+	clusterNames := []string{}
+	for _, decision := range placementDecision.Status.Decisions {
+		clusterNames = append(clusterNames, decision.ClusterName)
+	}
+	return clusterNames, nil
+}
+
 func indexPlacementsByScore(obj interface{}) ([]string, error) {
 	placement, ok := obj.(*clusterapiv1beta1.Placement)
 	if !ok {
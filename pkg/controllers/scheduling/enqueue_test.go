@@ -25,8 +25,11 @@ func newClusterInformerFactory(clusterClient clusterclient.Interface, objects ..
 	clusterInformerFactory.Cluster().V1beta1().Placements().Informer().AddIndexers(cache.Indexers{
 		placementsByScore:             indexPlacementsByScore,
 		placementsByClusterSetBinding: indexPlacementByClusterSetBinding,
+		placementsByPredicateKey:      indexPlacementByPredicateKey,
 	})
 
+	AddPlacementDecisionIndexer(clusterInformerFactory.Cluster().V1beta1().PlacementDecisions())
+
 	clusterInformerFactory.Cluster().V1beta2().ManagedClusterSetBindings().Informer().AddIndexers(cache.Indexers{
 		clustersetBindingsByClusterSet: indexClusterSetBindingByClusterSet,
 	})
@@ -180,6 +183,7 @@ func TestEnqueuePlacementsByClusterSet(t *testing.T) {
 				clusterInformerFactory.Cluster().V1beta2().ManagedClusterSets(),
 				clusterInformerFactory.Cluster().V1beta1().Placements(),
 				clusterInformerFactory.Cluster().V1beta2().ManagedClusterSetBindings(),
+				clusterInformerFactory.Cluster().V1beta1().PlacementDecisions(),
 			)
 			queuedKeys := sets.NewString()
 			fakeEnqueuePlacement := func(obj interface{}, queue workqueue.RateLimitingInterface) {
@@ -287,6 +291,7 @@ func TestEnqueuePlacementsByClusterSetBinding(t *testing.T) {
 				clusterInformerFactory.Cluster().V1beta2().ManagedClusterSets(),
 				clusterInformerFactory.Cluster().V1beta1().Placements(),
 				clusterInformerFactory.Cluster().V1beta2().ManagedClusterSetBindings(),
+				clusterInformerFactory.Cluster().V1beta1().PlacementDecisions(),
 			)
 			queuedKeys := sets.NewString()
 			fakeEnqueuePlacement := func(obj interface{}, queue workqueue.RateLimitingInterface) {
@@ -375,6 +380,7 @@ func TestEnqueuePlacementsByScore(t *testing.T) {
 				clusterInformerFactory.Cluster().V1beta2().ManagedClusterSets(),
 				clusterInformerFactory.Cluster().V1beta1().Placements(),
 				clusterInformerFactory.Cluster().V1beta2().ManagedClusterSetBindings(),
+				clusterInformerFactory.Cluster().V1beta1().PlacementDecisions(),
 			)
 			queuedKeys := sets.NewString()
 			fakeEnqueuePlacement := func(obj interface{}, queue workqueue.RateLimitingInterface) {
@@ -391,3 +397,107 @@ func TestEnqueuePlacementsByScore(t *testing.T) {
 		})
 	}
 }
+
+func TestEnqueueCluster(t *testing.T) {
+	placements := []runtime.Object{
+		testinghelpers.NewPlacement("ns1", "p-any").Build(),
+		testinghelpers.NewPlacement("ns1", "p-amazon").AddPredicate(
+			&metav1.LabelSelector{MatchLabels: map[string]string{"cloud": "Amazon"}}, nil).Build(),
+		testinghelpers.NewPlacement("ns1", "p-google").AddPredicate(
+			&metav1.LabelSelector{MatchLabels: map[string]string{"cloud": "Google"}}, nil).Build(),
+		testinghelpers.NewPlacement("ns1", "p-notprod").AddPredicate(
+			&metav1.LabelSelector{MatchExpressions: []metav1.LabelSelectorRequirement{
+				{Key: "env", Operator: metav1.LabelSelectorOpNotIn, Values: []string{"prod"}},
+			}}, nil).Build(),
+		testinghelpers.NewPlacement("ns1", "p-claim").AddPredicate(
+			nil, &clusterapiv1beta1.ClusterClaimSelector{MatchExpressions: []metav1.LabelSelectorRequirement{
+				{Key: "region", Operator: metav1.LabelSelectorOpIn, Values: []string{"us-east"}},
+			}}).Build(),
+		testinghelpers.NewPlacement("ns1", "p-selected").AddPredicate(
+			&metav1.LabelSelector{MatchLabels: map[string]string{"cloud": "Azure"}}, nil).Build(),
+		testinghelpers.NewPlacementDecision("ns1", "p-selected-decision-1").
+			WithLabel(placementLabel, "p-selected").WithDecisions("cluster1").Build(),
+		testinghelpers.NewPlacement("ns2", "p-unbound").Build(),
+		testinghelpers.NewClusterSet("clusterset1").Build(),
+		testinghelpers.NewClusterSet("clusterset2").Build(),
+		testinghelpers.NewClusterSetBinding("ns1", "clusterset1"),
+	}
+
+	cases := []struct {
+		name       string
+		clusters   []interface{}
+		queuedKeys []string
+	}{
+		{
+			name: "add cluster",
+			clusters: []interface{}{
+				testinghelpers.NewManagedCluster("cluster1").WithLabel(clusterSetLabel, "clusterset1").
+					WithLabel("cloud", "Amazon").Build(),
+			},
+			queuedKeys: []string{"ns1/p-any", "ns1/p-amazon", "ns1/p-notprod", "ns1/p-selected"},
+		},
+		{
+			name: "update label of cluster",
+			clusters: []interface{}{
+				testinghelpers.NewManagedCluster("cluster1").WithLabel(clusterSetLabel, "clusterset1").
+					WithLabel("cloud", "Amazon").Build(),
+				testinghelpers.NewManagedCluster("cluster1").WithLabel(clusterSetLabel, "clusterset1").
+					WithLabel("cloud", "Google").Build(),
+			},
+			queuedKeys: []string{"ns1/p-any", "ns1/p-amazon", "ns1/p-google", "ns1/p-notprod", "ns1/p-selected"},
+		},
+		{
+			name: "cluster with claim",
+			clusters: []interface{}{
+				testinghelpers.NewManagedCluster("cluster1").WithLabel(clusterSetLabel, "clusterset1").
+					WithLabel("env", "prod").WithClaim("region", "us-east").Build(),
+			},
+			queuedKeys: []string{"ns1/p-any", "ns1/p-claim", "ns1/p-selected"},
+		},
+		{
+			name: "move cluster out of bound clusterset",
+			clusters: []interface{}{
+				testinghelpers.NewManagedCluster("cluster2").WithLabel(clusterSetLabel, "clusterset1").Build(),
+				testinghelpers.NewManagedCluster("cluster2").WithLabel(clusterSetLabel, "clusterset2").Build(),
+			},
+			queuedKeys: []string{"ns1/p-any", "ns1/p-notprod"},
+		},
+		{
+			name: "cluster in unbound clusterset",
+			clusters: []interface{}{
+				testinghelpers.NewManagedCluster("cluster2").WithLabel(clusterSetLabel, "clusterset2").
+					WithLabel("cloud", "Amazon").Build(),
+			},
+			queuedKeys: []string{},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			clusterClient := clusterfake.NewSimpleClientset(placements...)
+			clusterInformerFactory := newClusterInformerFactory(clusterClient, placements...)
+
+			syncCtx := testinghelpers.NewFakeSyncContext(t, "fake")
+			q := newEnqueuer(
+				syncCtx.Queue(),
+				clusterInformerFactory.Cluster().V1().ManagedClusters(),
+				clusterInformerFactory.Cluster().V1beta2().ManagedClusterSets(),
+				clusterInformerFactory.Cluster().V1beta1().Placements(),
+				clusterInformerFactory.Cluster().V1beta2().ManagedClusterSetBindings(),
+				clusterInformerFactory.Cluster().V1beta1().PlacementDecisions(),
+			)
+			queuedKeys := sets.NewString()
+			fakeEnqueuePlacement := func(obj interface{}, queue workqueue.RateLimitingInterface) {
+				key, _ := cache.DeletionHandlingMetaNamespaceKeyFunc(obj)
+				queuedKeys.Insert(key)
+			}
+			q.enqueuePlacementFunc = fakeEnqueuePlacement
+			q.enqueueCluster(c.clusters...)
+
+			expectedQueuedKeys := sets.NewString(c.queuedKeys...)
+			if !queuedKeys.Equal(expectedQueuedKeys) {
+				t.Errorf("expected queued placements %q, but got %s", strings.Join(expectedQueuedKeys.List(), ","), strings.Join(queuedKeys.List(), ","))
+			}
+		})
+	}
+}
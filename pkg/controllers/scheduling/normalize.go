@@ -0,0 +1,113 @@
+package scheduling
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	clusterapiv1beta1 "open-cluster-management.io/api/cluster/v1beta1"
+	"open-cluster-management.io/placement/pkg/controllers/framework"
+	"open-cluster-management.io/placement/pkg/plugins"
+)
+
+// ScoreNormalizationMode defines how the scores of a prioritizer are normalized if any of them
+// is out of the range [MinClusterScore, MaxClusterScore].
+type ScoreNormalizationMode string
+
+const (
+	// ScoreNormalizationClamp sets the out-of-range scores to the nearest bound. It is the default.
+	ScoreNormalizationClamp ScoreNormalizationMode = "Clamp"
+	// ScoreNormalizationMinMax linearly rescales all the scores so that the minimal score becomes
+	// MinClusterScore and the maximal one becomes MaxClusterScore.
+	ScoreNormalizationMinMax ScoreNormalizationMode = "MinMax"
+	// ScoreNormalizationRank replaces the scores with their ranks, evenly spread between
+	// MinClusterScore and MaxClusterScore. Equal scores get the same rank.
+	ScoreNormalizationRank ScoreNormalizationMode = "Rank"
+)
+
+// ScoreNormalizationConfig specifies the normalization mode of a prioritizer.
+type ScoreNormalizationConfig struct {
+	ScoreCoordinate clusterapiv1beta1.ScoreCoordinate `json:"scoreCoordinate"`
+	Mode            ScoreNormalizationMode            `json:"mode"`
+}
+
+// normalizationMode returns the normalization mode of the given score coordinate. The score
+// coordinates are compared by value since the AddOn field is a pointer.
+func normalizationMode(configs []ScoreNormalizationConfig, sc clusterapiv1beta1.ScoreCoordinate) ScoreNormalizationMode {
+	for _, c := range configs {
+		if reflect.DeepEqual(c.ScoreCoordinate, sc) {
+			return c.Mode
+		}
+	}
+	return ScoreNormalizationClamp
+}
+
+// normalizeScores normalizes the scores of a prioritizer in place if any of them is out of
+// range. It returns a Warning status naming the out-of-range clusters and scores, and the mode
+// actually applied.
+func normalizeScores(prioritizerName string, mode ScoreNormalizationMode, scores map[string]int64) *framework.Status {
+	outOfRange := []string{}
+	var min, max int64
+	first := true
+	for name, score := range scores {
+		if score < plugins.MinClusterScore || score > plugins.MaxClusterScore {
+			outOfRange = append(outOfRange, fmt.Sprintf("%s=%d", name, score))
+		}
+		if first || score < min {
+			min = score
+		}
+		if first || score > max {
+			max = score
+		}
+		first = false
+	}
+	if len(outOfRange) == 0 {
+		return framework.NewStatus(prioritizerName, framework.Success, "")
+	}
+
+	applied := string(mode)
+	switch {
+	case mode == ScoreNormalizationMinMax && max > min:
+		// use float64 to avoid overflow with huge scores
+		scale := float64(plugins.MaxClusterScore-plugins.MinClusterScore) / (float64(max) - float64(min))
+		for name, score := range scores {
+			scores[name] = plugins.MinClusterScore + int64((float64(score)-float64(min))*scale)
+		}
+	case mode == ScoreNormalizationRank && max > min:
+		values := []int64{}
+		ranks := map[int64]int{}
+		for _, score := range scores {
+			if _, ok := ranks[score]; !ok {
+				ranks[score] = 0
+				values = append(values, score)
+			}
+		}
+		sort.Slice(values, func(i, j int) bool { return values[i] < values[j] })
+		for i, v := range values {
+			ranks[v] = i
+		}
+		scoreRange := plugins.MaxClusterScore - plugins.MinClusterScore
+		for name, score := range scores {
+			scores[name] = plugins.MinClusterScore + scoreRange*int64(ranks[score])/int64(len(values)-1)
+		}
+	default:
+		// the scores of MinMax and Rank can not be spread if they are all equal
+		if mode != ScoreNormalizationClamp {
+			applied = fmt.Sprintf("%s instead of %s since all the scores are equal", ScoreNormalizationClamp, mode)
+		}
+		for name, score := range scores {
+			switch {
+			case score < plugins.MinClusterScore:
+				scores[name] = plugins.MinClusterScore
+			case score > plugins.MaxClusterScore:
+				scores[name] = plugins.MaxClusterScore
+			}
+		}
+	}
+
+	sort.Strings(outOfRange)
+	msg := fmt.Sprintf("prioritizer %s returned scores out of range [%d, %d]: %s, normalized by %s",
+		prioritizerName, plugins.MinClusterScore, plugins.MaxClusterScore, strings.Join(outOfRange, ", "), applied)
+	return framework.NewStatus(prioritizerName, framework.Warning, msg)
+}
@@ -0,0 +1,118 @@
+package scheduling
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	clusterapiv1beta1 "open-cluster-management.io/api/cluster/v1beta1"
+	"open-cluster-management.io/placement/pkg/controllers/framework"
+)
+
+func TestNormalizeScores(t *testing.T) {
+	cases := []struct {
+		name           string
+		mode           ScoreNormalizationMode
+		scores         map[string]int64
+		expectedScores map[string]int64
+		expectedCode   framework.Code
+		expectedMsg    string
+	}{
+		{
+			name:           "scores in range",
+			mode:           ScoreNormalizationMinMax,
+			scores:         map[string]int64{"cluster1": -100, "cluster2": 0, "cluster3": 100},
+			expectedScores: map[string]int64{"cluster1": -100, "cluster2": 0, "cluster3": 100},
+			expectedCode:   framework.Success,
+		},
+		{
+			name:           "clamp",
+			mode:           ScoreNormalizationClamp,
+			scores:         map[string]int64{"cluster1": -1000, "cluster2": 50, "cluster3": 1000000000},
+			expectedScores: map[string]int64{"cluster1": -100, "cluster2": 50, "cluster3": 100},
+			expectedCode:   framework.Warning,
+			expectedMsg:    "cluster1=-1000, cluster3=1000000000, normalized by Clamp",
+		},
+		{
+			name:           "min-max",
+			mode:           ScoreNormalizationMinMax,
+			scores:         map[string]int64{"cluster1": 0, "cluster2": 500, "cluster3": 1000},
+			expectedScores: map[string]int64{"cluster1": -100, "cluster2": 0, "cluster3": 100},
+			expectedCode:   framework.Warning,
+			expectedMsg:    "cluster3=1000, normalized by MinMax",
+		},
+		{
+			name:           "min-max with scores all above the range",
+			mode:           ScoreNormalizationMinMax,
+			scores:         map[string]int64{"cluster1": 500, "cluster2": 1000},
+			expectedScores: map[string]int64{"cluster1": -100, "cluster2": 100},
+			expectedCode:   framework.Warning,
+			expectedMsg:    "normalized by MinMax",
+		},
+		{
+			name:           "min-max with equal scores",
+			mode:           ScoreNormalizationMinMax,
+			scores:         map[string]int64{"cluster1": 1000, "cluster2": 1000},
+			expectedScores: map[string]int64{"cluster1": 100, "cluster2": 100},
+			expectedCode:   framework.Warning,
+			expectedMsg:    "normalized by Clamp instead of MinMax since all the scores are equal",
+		},
+		{
+			name:           "rank with equal scores",
+			mode:           ScoreNormalizationRank,
+			scores:         map[string]int64{"cluster1": -1000},
+			expectedScores: map[string]int64{"cluster1": -100},
+			expectedCode:   framework.Warning,
+			expectedMsg:    "normalized by Clamp instead of Rank since all the scores are equal",
+		},
+		{
+			name:           "rank",
+			mode:           ScoreNormalizationRank,
+			scores:         map[string]int64{"cluster1": 1, "cluster2": 1000000000, "cluster3": 10, "cluster4": 10},
+			expectedScores: map[string]int64{"cluster1": -100, "cluster2": 100, "cluster3": 0, "cluster4": 0},
+			expectedCode:   framework.Warning,
+			expectedMsg:    "cluster2=1000000000, normalized by Rank",
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			status := normalizeScores("test", c.mode, c.scores)
+			if status.Code() != c.expectedCode {
+				t.Errorf("expected status code %v, but got %v", c.expectedCode, status.Code())
+			}
+			if !strings.Contains(status.Message(), c.expectedMsg) {
+				t.Errorf("expected message containing %q, but got %q", c.expectedMsg, status.Message())
+			}
+			if !reflect.DeepEqual(c.scores, c.expectedScores) {
+				t.Errorf("expected scores %v, but got %v", c.expectedScores, c.scores)
+			}
+		})
+	}
+}
+
+func TestNormalizationMode(t *testing.T) {
+	configs := []ScoreNormalizationConfig{
+		{
+			ScoreCoordinate: clusterapiv1beta1.ScoreCoordinate{
+				Type:  clusterapiv1beta1.ScoreCoordinateTypeAddOn,
+				AddOn: &clusterapiv1beta1.AddOnScore{ResourceName: "demo", ScoreName: "demo"},
+			},
+			Mode: ScoreNormalizationRank,
+		},
+	}
+
+	// the AddOn pointers differ, but the score coordinates are equal
+	sc := clusterapiv1beta1.ScoreCoordinate{
+		Type:  clusterapiv1beta1.ScoreCoordinateTypeAddOn,
+		AddOn: &clusterapiv1beta1.AddOnScore{ResourceName: "demo", ScoreName: "demo"},
+	}
+	if mode := normalizationMode(configs, sc); mode != ScoreNormalizationRank {
+		t.Errorf("expected mode %s, but got %s", ScoreNormalizationRank, mode)
+	}
+
+	sc.AddOn.ScoreName = "other"
+	if mode := normalizationMode(configs, sc); mode != ScoreNormalizationClamp {
+		t.Errorf("expected mode %s, but got %s", ScoreNormalizationClamp, mode)
+	}
+}
@@ -0,0 +1,245 @@
+package scheduling
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"go.opentelemetry.io/otel/trace"
+	"k8s.io/client-go/util/workqueue"
+	clusterapiv1 "open-cluster-management.io/api/cluster/v1"
+	clusterapiv1beta1 "open-cluster-management.io/api/cluster/v1beta1"
+	"open-cluster-management.io/placement/pkg/controllers/framework"
+	"open-cluster-management.io/placement/pkg/controllers/metrics"
+	"open-cluster-management.io/placement/pkg/controllers/tracing"
+	"open-cluster-management.io/placement/pkg/plugins"
+)
+
+const (
+	// DefaultParallelism is the number of workers used to filter and score clusters if the
+	// parallelism is not set in the scheduler configuration.
+	DefaultParallelism = 16
+
+	// minClustersPerChunk avoids the overhead of splitting small fleets into chunks.
+	minClustersPerChunk = 100
+)
+
+// parallelizer runs the filters and prioritizers with a bounded number of workers. The results
+// are merged in the order of the clusters, so they are the same as running sequentially.
+type parallelizer struct {
+	parallelism int
+}
+
+func newParallelizer(parallelism int) parallelizer {
+	if parallelism <= 0 {
+		parallelism = DefaultParallelism
+	}
+	return parallelizer{parallelism: parallelism}
+}
+
+// chunks splits the clusters into chunks for a plugin which is independent per cluster.
+// Otherwise all the clusters are in one chunk.
+func (p parallelizer) chunks(plugin plugins.Plugin, clusters []*clusterapiv1.ManagedCluster) [][]*clusterapiv1.ManagedCluster {
+	if ci, ok := plugin.(plugins.ClusterIndependent); !ok || !ci.ClusterIndependent() {
+		return [][]*clusterapiv1.ManagedCluster{clusters}
+	}
+
+	chunkSize := (len(clusters) + p.parallelism - 1) / p.parallelism
+	if chunkSize < minClustersPerChunk {
+		chunkSize = minClustersPerChunk
+	}
+
+	chunks := [][]*clusterapiv1.ManagedCluster{}
+	for start := 0; start < len(clusters); start += chunkSize {
+		end := start + chunkSize
+		if end > len(clusters) {
+			end = len(clusters)
+		}
+		chunks = append(chunks, clusters[start:end])
+	}
+	if len(chunks) == 0 {
+		chunks = append(chunks, clusters)
+	}
+	return chunks
+}
+
+// filter runs the filter with the clusters split into chunks concurrently.
+func (p parallelizer) filter(
+	ctx context.Context,
+	f plugins.Filter,
+	placement *clusterapiv1beta1.Placement,
+	clusters []*clusterapiv1.ManagedCluster,
+) (plugins.PluginFilterResult, *framework.Status) {
+	ctx, span := tracing.Tracer().Start(ctx, "Filter",
+		trace.WithAttributes(tracing.PluginKey.String(f.Name()), tracing.ClustersKey.Int(len(clusters))))
+	defer span.End()
+
+	result, status := p.runFilter(ctx, f, placement, clusters)
+	span.SetAttributes(tracing.FilteredClustersKey.Int(len(result.Filtered)))
+	tracing.RecordStatus(span, status)
+	return result, status
+}
+
+func (p parallelizer) runFilter(
+	ctx context.Context,
+	f plugins.Filter,
+	placement *clusterapiv1beta1.Placement,
+	clusters []*clusterapiv1.ManagedCluster,
+) (plugins.PluginFilterResult, *framework.Status) {
+	chunks := p.chunks(f, clusters)
+	if len(chunks) == 1 {
+		return timedFilter(ctx, f, placement, clusters)
+	}
+
+	results := make([]plugins.PluginFilterResult, len(chunks))
+	statuses := make([]*framework.Status, len(chunks))
+	workqueue.ParallelizeUntil(ctx, p.parallelism, len(chunks), func(i int) {
+		results[i], statuses[i] = timedFilter(ctx, f, placement, chunks[i])
+	})
+
+	merged := plugins.PluginFilterResult{Filtered: []*clusterapiv1.ManagedCluster{}}
+	for _, r := range results {
+		merged.Filtered = append(merged.Filtered, r.Filtered...)
+		for name, reason := range r.Rejected {
+			if merged.Rejected == nil {
+				merged.Rejected = map[string]string{}
+			}
+			merged.Rejected[name] = reason
+		}
+	}
+	return merged, mergeStatuses(f.Name(), statuses)
+}
+
+// timedFilter runs the filter and records its duration.
+func timedFilter(
+	ctx context.Context,
+	f plugins.Filter,
+	placement *clusterapiv1beta1.Placement,
+	clusters []*clusterapiv1.ManagedCluster,
+) (plugins.PluginFilterResult, *framework.Status) {
+	defer metrics.ObservePluginDuration(f.Name(), metrics.ExtensionPointFilter, time.Now())
+	return f.Filter(ctx, placement, clusters)
+}
+
+// prioritizerResult is the merged score result of a prioritizer.
+type prioritizerResult struct {
+	scoreCoordinate clusterapiv1beta1.ScoreCoordinate
+	prioritizer     plugins.Prioritizer
+	scores          map[string]int64
+	status          *framework.Status
+}
+
+// score runs all the prioritizers concurrently, with the clusters split into chunks for each
+// of them. The results are ordered by the score coordinates.
+func (p parallelizer) score(
+	ctx context.Context,
+	prioritizers map[clusterapiv1beta1.ScoreCoordinate]plugins.Prioritizer,
+	placement *clusterapiv1beta1.Placement,
+	clusters []*clusterapiv1.ManagedCluster,
+) []prioritizerResult {
+	type piece struct {
+		prioritizer int
+		clusters    []*clusterapiv1.ManagedCluster
+	}
+
+	results := []prioritizerResult{}
+	for sc, prioritizer := range prioritizers {
+		results = append(results, prioritizerResult{scoreCoordinate: sc, prioritizer: prioritizer})
+	}
+	sort.Slice(results, func(i, j int) bool {
+		return scoreCoordinateKey(results[i].scoreCoordinate) < scoreCoordinateKey(results[j].scoreCoordinate)
+	})
+
+	pieces := []piece{}
+	for i, r := range results {
+		for _, chunk := range p.chunks(r.prioritizer, clusters) {
+			pieces = append(pieces, piece{prioritizer: i, clusters: chunk})
+		}
+	}
+
+	scores := make([]map[string]int64, len(pieces))
+	statuses := make([]*framework.Status, len(pieces))
+	starts := make([]time.Time, len(pieces))
+	ends := make([]time.Time, len(pieces))
+	workqueue.ParallelizeUntil(ctx, p.parallelism, len(pieces), func(i int) {
+		prioritizer := results[pieces[i].prioritizer].prioritizer
+		starts[i] = time.Now()
+		result, status := prioritizer.Score(ctx, placement, pieces[i].clusters)
+		ends[i] = time.Now()
+		metrics.ObservePluginDuration(prioritizer.Name(), metrics.ExtensionPointScore, starts[i])
+		scores[i], statuses[i] = result.Scores, status
+	})
+
+	// merge the results of the chunks, pieces of the same prioritizer are adjacent
+	for start := 0; start < len(pieces); {
+		end := start
+		merged := map[string]int64{}
+		for end < len(pieces) && pieces[end].prioritizer == pieces[start].prioritizer {
+			for name, score := range scores[end] {
+				merged[name] = score
+			}
+			end++
+		}
+
+		r := &results[pieces[start].prioritizer]
+		r.scores = merged
+		if end-start == 1 {
+			r.status = statuses[start]
+		} else {
+			r.status = mergeStatuses(r.prioritizer.Name(), statuses[start:end])
+		}
+		traceScore(ctx, r, len(clusters), starts[start:end], ends[start:end])
+		start = end
+	}
+	return results
+}
+
+// traceScore records the span of a prioritizer once all of its chunks are scored. The span
+// starts when the first chunk starts and ends when the last chunk ends.
+func traceScore(ctx context.Context, r *prioritizerResult, numOfClusters int, starts, ends []time.Time) {
+	start, end := starts[0], ends[0]
+	for i := range starts {
+		if starts[i].Before(start) {
+			start = starts[i]
+		}
+		if ends[i].After(end) {
+			end = ends[i]
+		}
+	}
+
+	_, span := tracing.Tracer().Start(ctx, "Score", trace.WithTimestamp(start), trace.WithAttributes(
+		tracing.PluginKey.String(r.prioritizer.Name()),
+		tracing.ClustersKey.Int(numOfClusters),
+		tracing.ScoresAttribute(r.scores),
+	))
+	tracing.RecordStatus(span, r.status)
+	span.End(trace.WithTimestamp(end))
+}
+
+// mergeStatuses returns the first error status of the chunks. If none of them fails, the
+// messages of the warning statuses are merged.
+func mergeStatuses(pluginName string, statuses []*framework.Status) *framework.Status {
+	merged := framework.NewStatus(pluginName, framework.Success)
+	for _, status := range statuses {
+		switch {
+		case status.IsError():
+			return status
+		case status.Code() == framework.Warning:
+			if merged.IsSuccess() {
+				merged = framework.NewStatus(pluginName, framework.Warning)
+			}
+			merged.AppendReason(status.Message())
+		}
+	}
+	return merged
+}
+
+// scoreCoordinateKey returns a string which identifies the score coordinate, the AddOn field
+// is compared by value.
+func scoreCoordinateKey(sc clusterapiv1beta1.ScoreCoordinate) string {
+	if sc.AddOn != nil {
+		return fmt.Sprintf("%s/%s/%s/%s", sc.Type, sc.BuiltIn, sc.AddOn.ResourceName, sc.AddOn.ScoreName)
+	}
+	return fmt.Sprintf("%s/%s", sc.Type, sc.BuiltIn)
+}
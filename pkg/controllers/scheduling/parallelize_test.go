@@ -0,0 +1,226 @@
+package scheduling
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sort"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clusterfake "open-cluster-management.io/api/client/cluster/clientset/versioned/fake"
+	clusterapiv1 "open-cluster-management.io/api/cluster/v1"
+	clusterapiv1beta1 "open-cluster-management.io/api/cluster/v1beta1"
+	"open-cluster-management.io/placement/pkg/controllers/framework"
+	"open-cluster-management.io/placement/pkg/controllers/tracing"
+	testinghelpers "open-cluster-management.io/placement/pkg/helpers/testing"
+	"open-cluster-management.io/placement/pkg/plugins"
+	"open-cluster-management.io/placement/pkg/plugins/balance"
+	"open-cluster-management.io/placement/pkg/plugins/predicate"
+)
+
+// newLargeFleet returns a placement and clusters where some clusters do not match the
+// predicate, some are tainted and the addon scores vary.
+func newLargeFleet(numOfClusters int) (*clusterapiv1beta1.Placement, []*clusterapiv1.ManagedCluster, []runtime.Object) {
+	placement := testinghelpers.NewPlacement("ns1", "placement1").WithNOC(10).
+		AddPredicate(&metav1.LabelSelector{MatchLabels: map[string]string{"env": "prod"}}, nil).
+		WithPrioritizerPolicy(clusterapiv1beta1.PrioritizerPolicyModeAdditive).
+		WithScoreCoordinateAddOn("demo", "demo", 1).Build()
+
+	objs := []runtime.Object{}
+	clusters := []*clusterapiv1.ManagedCluster{}
+	for i := 0; i < numOfClusters; i++ {
+		name := fmt.Sprintf("cluster%d", i)
+		builder := testinghelpers.NewManagedCluster(name)
+		if i%3 != 0 {
+			builder = builder.WithLabel("env", "prod")
+		}
+		if i%7 == 0 {
+			builder = builder.WithTaint(&clusterapiv1.Taint{Key: "key", Effect: clusterapiv1.TaintEffectNoSelect})
+		}
+		clusters = append(clusters, builder.Build())
+		objs = append(objs, testinghelpers.NewAddOnPlacementScore(name, "demo").WithScore("demo", int32(i%50)).Build())
+	}
+	return placement, clusters, objs
+}
+
+func newSchedulerWithParallelism(t testing.TB, parallelism int32, objs []runtime.Object) *pluginScheduler {
+	config := NewDefaultSchedulerConfiguration()
+	config.Parallelism = parallelism
+
+	handle := testinghelpers.NewFakePluginHandle(t, clusterfake.NewSimpleClientset(), objs...)
+	s, err := NewPluginSchedulerWithConfig(handle, config)
+	if err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+	return s
+}
+
+func TestParallelScheduleIsDeterministic(t *testing.T) {
+	placement, clusters, objs := newLargeFleet(1000)
+
+	sequential := newSchedulerWithParallelism(t, 1, objs)
+	expected, status := sequential.Schedule(context.TODO(), placement, clusters)
+	if status.IsError() {
+		t.Fatalf("unexpected err: %v", status.AsError())
+	}
+
+	for i := 0; i < 5; i++ {
+		parallel := newSchedulerWithParallelism(t, 16, objs)
+		actual, status := parallel.Schedule(context.TODO(), placement, clusters)
+		if status.IsError() {
+			t.Fatalf("unexpected err: %v", status.AsError())
+		}
+
+		if !reflect.DeepEqual(actual.FilterResults(), expected.FilterResults()) {
+			t.Errorf("expected filter results %v, but got %v", expected.FilterResults(), actual.FilterResults())
+		}
+		if !reflect.DeepEqual(actual.PrioritizerResults(), expected.PrioritizerResults()) {
+			t.Errorf("expected prioritizer results %v, but got %v", expected.PrioritizerResults(), actual.PrioritizerResults())
+		}
+		if !reflect.DeepEqual(actual.Decisions(), expected.Decisions()) {
+			t.Errorf("expected decisions %v, but got %v", expected.Decisions(), actual.Decisions())
+		}
+	}
+}
+
+func TestParallelScheduleSpans(t *testing.T) {
+	recorder := testinghelpers.NewSpanRecorder(t)
+
+	placement, clusters, objs := newLargeFleet(300)
+	s := newSchedulerWithParallelism(t, 16, objs)
+	if _, status := s.Schedule(context.TODO(), placement, clusters); status.IsError() {
+		t.Fatalf("unexpected err: %v", status.AsError())
+	}
+
+	plugins := map[string][]string{}
+	for _, span := range recorder.Spans() {
+		for _, attr := range span.Attributes() {
+			if attr.Key == tracing.PluginKey {
+				plugins[span.Name()] = append(plugins[span.Name()], attr.Value.AsString())
+			}
+		}
+	}
+	sort.Strings(plugins["Score"])
+
+	expected := map[string][]string{
+		"Filter": {"Predicate", "TaintToleration", "KubernetesVersion", "CELPredicate"},
+		"Score":  {"AddOn/demo/demo", "Balance", "Steady", "TaintToleration"},
+	}
+	if !reflect.DeepEqual(plugins, expected) {
+		t.Errorf("expected spans of plugins %v, but got %v", expected, plugins)
+	}
+}
+
+func TestChunks(t *testing.T) {
+	_, clusters, _ := newLargeFleet(1000)
+
+	cases := []struct {
+		name           string
+		parallelism    int
+		numOfClusters  int
+		independent    bool
+		expectedChunks int
+	}{
+		{
+			name:           "sequential",
+			parallelism:    1,
+			numOfClusters:  1000,
+			independent:    true,
+			expectedChunks: 1,
+		},
+		{
+			name:           "cluster dependent plugin",
+			parallelism:    16,
+			numOfClusters:  1000,
+			independent:    false,
+			expectedChunks: 1,
+		},
+		{
+			name:           "small fleet",
+			parallelism:    16,
+			numOfClusters:  50,
+			independent:    true,
+			expectedChunks: 1,
+		},
+		{
+			name:           "minimal chunk size",
+			parallelism:    16,
+			numOfClusters:  1000,
+			independent:    true,
+			expectedChunks: 10,
+		},
+		{
+			name:           "chunk per worker",
+			parallelism:    4,
+			numOfClusters:  1000,
+			independent:    true,
+			expectedChunks: 4,
+		},
+		{
+			name:           "no cluster",
+			parallelism:    16,
+			numOfClusters:  0,
+			independent:    true,
+			expectedChunks: 1,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			var plugin plugins.Plugin = balance.New(nil)
+			if c.independent {
+				plugin = predicate.New(nil)
+			}
+
+			chunks := newParallelizer(c.parallelism).chunks(plugin, clusters[:c.numOfClusters])
+			if len(chunks) != c.expectedChunks {
+				t.Errorf("expected %d chunks, but got %d", c.expectedChunks, len(chunks))
+			}
+
+			actual := []*clusterapiv1.ManagedCluster{}
+			for _, chunk := range chunks {
+				actual = append(actual, chunk...)
+			}
+			if !reflect.DeepEqual(actual, clusters[:c.numOfClusters]) {
+				t.Errorf("expected the chunks to contain all the clusters in order")
+			}
+		})
+	}
+}
+
+func TestMergeStatuses(t *testing.T) {
+	status := mergeStatuses("test", []*framework.Status{
+		framework.NewStatus("test", framework.Success, ""),
+		framework.NewStatus("test", framework.Warning, "chunk1"),
+		framework.NewStatus("test", framework.Warning, "chunk2"),
+	})
+	if status.Code() != framework.Warning || status.Message() != "chunk1, chunk2" {
+		t.Errorf("expected merged warning, but got %v: %s", status.Code(), status.Message())
+	}
+
+	status = mergeStatuses("test", []*framework.Status{
+		framework.NewStatus("test", framework.Warning, "chunk0"),
+		framework.NewStatus("test", framework.Error, "chunk1"),
+	})
+	if status.Code() != framework.Error || status.Message() != "chunk1" {
+		t.Errorf("expected error, but got %v: %s", status.Code(), status.Message())
+	}
+}
+
+func BenchmarkSchedule(b *testing.B) {
+	placement, clusters, objs := newLargeFleet(5000)
+
+	for _, parallelism := range []int32{1, 16} {
+		b.Run(fmt.Sprintf("parallelism-%d", parallelism), func(b *testing.B) {
+			s := newSchedulerWithParallelism(b, parallelism, objs)
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				if _, status := s.Schedule(context.TODO(), placement, clusters); status.IsError() {
+					b.Fatalf("unexpected err: %v", status.AsError())
+				}
+			}
+		})
+	}
+}
@@ -2,11 +2,15 @@ package scheduling
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"sort"
 	"strings"
+	"sync"
 	"time"
 
+	"k8s.io/apimachinery/pkg/util/sets"
+	coordinationlisterv1 "k8s.io/client-go/listers/coordination/v1"
 	kevents "k8s.io/client-go/tools/events"
 	"k8s.io/klog/v2"
 	clusterclient "open-cluster-management.io/api/client/cluster/clientset/versioned"
@@ -16,10 +20,11 @@ import (
 	clusterapiv1 "open-cluster-management.io/api/cluster/v1"
 	clusterapiv1beta1 "open-cluster-management.io/api/cluster/v1beta1"
 	"open-cluster-management.io/placement/pkg/controllers/framework"
+	schedulingcache "open-cluster-management.io/placement/pkg/controllers/scheduling/cache"
 	"open-cluster-management.io/placement/pkg/plugins"
 	"open-cluster-management.io/placement/pkg/plugins/addon"
 	"open-cluster-management.io/placement/pkg/plugins/balance"
-	"open-cluster-management.io/placement/pkg/plugins/predicate"
+	"open-cluster-management.io/placement/pkg/plugins/extender"
 	"open-cluster-management.io/placement/pkg/plugins/resource"
 	"open-cluster-management.io/placement/pkg/plugins/steady"
 	"open-cluster-management.io/placement/pkg/plugins/tainttoleration"
@@ -28,6 +33,7 @@ import (
 const (
 	PrioritizerBalance                   string = "Balance"
 	PrioritizerSteady                    string = "Steady"
+	PrioritizerTaintToleration           string = "TaintToleration"
 	PrioritizerResourceAllocatableCPU    string = "ResourceAllocatableCPU"
 	PrioritizerResourceAllocatableMemory string = "ResourceAllocatableMemory"
 )
@@ -64,9 +70,33 @@ type ScheduleResult interface {
 	RequeueAfter() *time.Duration
 }
 
+// DecisionReason explains why a cluster is selected by the placement. It is encoded as JSON
+// and set as the reason of the ClusterDecision. The placementdecisions are not rewritten only for
+// changed scores or selection, so they are the ones when the decisions last changed.
+type DecisionReason struct {
+	// Filters are the filters the cluster passed, in the order they ran.
+	Filters []string `json:"filters"`
+	// Score is the total score of the cluster.
+	Score int64 `json:"score"`
+	// Prioritizers contains the weighted score each prioritizer contributes to the total score.
+	Prioritizers map[string]int64 `json:"prioritizers,omitempty"`
+	// Selection is Steady if the cluster is kept from the existing decisions, otherwise New.
+	Selection string `json:"selection"`
+}
+
+const (
+	// SelectionSteady means the cluster is already selected by the placement and kept.
+	SelectionSteady = "Steady"
+	// SelectionNew means the cluster is newly selected by the placement.
+	SelectionNew = "New"
+)
+
 type FilterResult struct {
 	Name             string   `json:"name"`
 	FilteredClusters []string `json:"filteredClusters"`
+	// RejectedClusters explains why the clusters are filtered out by the last filter, if the
+	// filter reports it.
+	RejectedClusters map[string]string `json:"rejectedClusters,omitempty"`
 }
 
 // PrioritizerResult defines the result of one prioritizer,
@@ -84,6 +114,7 @@ type scheduleResult struct {
 	unscheduledDecisions int
 
 	filteredRecords map[string][]*clusterapiv1.ManagedCluster
+	rejectedRecords map[string]map[string]string
 	scoreRecords    []PrioritizerResult
 	scoreSum        PrioritizerScore
 	requeueAfter    *time.Duration
@@ -94,17 +125,21 @@ type schedulerHandler struct {
 	placementDecisionLister clusterlisterv1beta1.PlacementDecisionLister
 	scoreLister             clusterlisterv1alpha1.AddOnPlacementScoreLister
 	clusterLister           clusterlisterv1.ManagedClusterLister
+	leaseLister             coordinationlisterv1.LeaseLister
 	clusterClient           clusterclient.Interface
+	decisionCache           *schedulingcache.Cache
 }
 
 func NewSchedulerHandler(
-	clusterClient clusterclient.Interface, placementDecisionLister clusterlisterv1beta1.PlacementDecisionLister, scoreLister clusterlisterv1alpha1.AddOnPlacementScoreLister, clusterLister clusterlisterv1.ManagedClusterLister, recorder kevents.EventRecorder) plugins.Handle {
+	clusterClient clusterclient.Interface, placementDecisionLister clusterlisterv1beta1.PlacementDecisionLister, scoreLister clusterlisterv1alpha1.AddOnPlacementScoreLister, clusterLister clusterlisterv1.ManagedClusterLister, leaseLister coordinationlisterv1.LeaseLister, decisionCache *schedulingcache.Cache, recorder kevents.EventRecorder) plugins.Handle {
 
 	return &schedulerHandler{
+		decisionCache:           decisionCache,
 		recorder:                recorder,
 		placementDecisionLister: placementDecisionLister,
 		scoreLister:             scoreLister,
 		clusterLister:           clusterLister,
+		leaseLister:             leaseLister,
 		clusterClient:           clusterClient,
 	}
 }
@@ -125,12 +160,34 @@ func (s *schedulerHandler) ClusterLister() clusterlisterv1.ManagedClusterLister
 	return s.clusterLister
 }
 
+func (s *schedulerHandler) LeaseLister() coordinationlisterv1.LeaseLister {
+	return s.leaseLister
+}
+
 func (s *schedulerHandler) ClusterClient() clusterclient.Interface {
 	return s.clusterClient
 }
 
+func (s *schedulerHandler) Snapshot(ctx context.Context) *schedulingcache.Snapshot {
+	if snapshot := schedulingcache.SnapshotFromContext(ctx); snapshot != nil {
+		return snapshot
+	}
+	return s.decisionCache.Snapshot()
+}
+
+func (s *schedulerHandler) DecisionCache() *schedulingcache.Cache {
+	return s.decisionCache
+}
+
+// decisionCacheProvider is implemented by the handles which maintain a decision cache. The
+// scheduler takes a snapshot of the cache at the beginning of each scheduling cycle, passes it
+// to the plugins in the context, and assumes the reserved decisions in the cache.
+type decisionCacheProvider interface {
+	DecisionCache() *schedulingcache.Cache
+}
+
 // Initialize the default prioritizer weight.
-// Balane and Steady weight 1, others weight 0.
+// Balane, Steady and TaintToleration weight 1, others weight 0.
 // The default weight can be replaced by each placement's PrioritizerConfigs.
 var defaultPrioritizerConfig = map[clusterapiv1beta1.ScoreCoordinate]int32{
 	{
@@ -141,22 +198,199 @@ var defaultPrioritizerConfig = map[clusterapiv1beta1.ScoreCoordinate]int32{
 		Type:    clusterapiv1beta1.ScoreCoordinateTypeBuiltIn,
 		BuiltIn: PrioritizerSteady,
 	}: 1,
+	{
+		Type:    clusterapiv1beta1.ScoreCoordinateTypeBuiltIn,
+		BuiltIn: PrioritizerTaintToleration,
+	}: 1,
+}
+
+// BindingPlugins is implemented by a Scheduler which runs plugins around writing the
+// decisions to PlacementDecisions.
+type BindingPlugins interface {
+	// Reserve assumes the decisions in the decision cache, so that they are seen by the scheduling of other
+	// placements before they are written, and runs the Reserve plugins. If any of them fails,
+	// the reserved ones are unreserved.
+	Reserve(ctx context.Context, placement *clusterapiv1beta1.Placement, decisions []clusterapiv1beta1.ClusterDecision) *framework.Status
+	// Unreserve runs the Unreserve of the Reserve plugins in the reverse order and forgets the
+	// assumed decisions.
+	Unreserve(ctx context.Context, placement *clusterapiv1beta1.Placement, decisions []clusterapiv1beta1.ClusterDecision)
+	// PostBind runs the PostBind plugins.
+	PostBind(ctx context.Context, placement *clusterapiv1beta1.Placement, decisions []clusterapiv1beta1.ClusterDecision)
 }
 
 type pluginScheduler struct {
 	handle             plugins.Handle
+	preFilters         []plugins.PreFilter
 	filters            []plugins.Filter
+	postFilters        []plugins.PostFilter
+	reservers          []plugins.Reserve
+	postBinders        []plugins.PostBind
 	prioritizerWeights map[clusterapiv1beta1.ScoreCoordinate]int32
+	scoreNormalization []ScoreNormalizationConfig
+	parallelizer       parallelizer
+	// extenders are the prioritizers of HTTP extenders, keyed by the extender names.
+	extenders map[string]plugins.Prioritizer
+	// decisionCache contains the decisions of all the placements, including the reserved ones
+	// which are not yet written. It is nil if the handle does not provide one.
+	decisionCache *schedulingcache.Cache
+	// cacheLock serializes taking the snapshot of the decision cache and reserving the decisions
+	// across the scheduling cycles. The filters and prioritizers in between, like the HTTP
+	// extenders, run concurrently, so a cycle may not see the decisions reserved by the
+	// concurrent ones.
+	cacheLock sync.Mutex
 }
 
+var _ BindingPlugins = &pluginScheduler{}
+var _ plugins.ClusterFieldsReader = &pluginScheduler{}
+
+// NewPluginScheduler returns a scheduler with the default filters and prioritizer weights.
 func NewPluginScheduler(handle plugins.Handle) *pluginScheduler {
-	return &pluginScheduler{
-		handle: handle,
-		filters: []plugins.Filter{
-			predicate.New(handle),
-			tainttoleration.New(handle),
-		},
-		prioritizerWeights: defaultPrioritizerConfig,
+	// the default configuration is always valid
+	scheduler, _ := NewPluginSchedulerWithConfig(handle, NewDefaultSchedulerConfiguration())
+	return scheduler
+}
+
+// NewPluginSchedulerWithConfig returns a scheduler with the filters and default prioritizer
+// weights in the given scheduler configuration.
+func NewPluginSchedulerWithConfig(handle plugins.Handle, config *SchedulerConfiguration) (*pluginScheduler, error) {
+	if err := ValidateSchedulerConfiguration(config); err != nil {
+		return nil, err
+	}
+
+	scheduler := &pluginScheduler{
+		handle:             handle,
+		scoreNormalization: config.ScoreNormalization,
+		parallelizer:       newParallelizer(int(config.Parallelism)),
+		extenders:          map[string]plugins.Prioritizer{},
+	}
+
+	// each plugin is built once and registered at all the extension points it implements
+	for _, name := range config.filterNames() {
+		plugin, err := pluginRegistry[name](handle, config.pluginArgs(name))
+		if err != nil {
+			return nil, fmt.Errorf("failed to build filter %s: %v", name, err)
+		}
+		filter, ok := plugin.(plugins.Filter)
+		if !ok {
+			return nil, fmt.Errorf("plugin %s is not a filter", name)
+		}
+		scheduler.filters = append(scheduler.filters, filter)
+		scheduler.addPlugin(plugin)
+	}
+	for _, p := range config.Plugins {
+		if sets.NewString(config.filterNames()...).Has(p.Name) {
+			continue
+		}
+		plugin, err := pluginRegistry[p.Name](handle, config.pluginArgs(p.Name))
+		if err != nil {
+			return nil, fmt.Errorf("failed to build plugin %s: %v", p.Name, err)
+		}
+		scheduler.addPlugin(plugin)
+	}
+
+	// the weights of extender prioritizers are added to the default weights, which can be
+	// overridden by the prioritizers in the configuration.
+	defaultWeights := map[clusterapiv1beta1.ScoreCoordinate]int32{}
+	for sc, w := range defaultPrioritizerConfig {
+		defaultWeights[sc] = w
+	}
+
+	for _, c := range config.Extenders {
+		e, err := extender.New(c)
+		if err != nil {
+			return nil, fmt.Errorf("failed to build extender %s: %v", c.Name, err)
+		}
+		if e.IsFilter() {
+			scheduler.filters = append(scheduler.filters, e)
+		}
+		if e.IsPrioritizer() {
+			scheduler.extenders[e.Name()] = e
+			defaultWeights[clusterapiv1beta1.ScoreCoordinate{
+				Type:    clusterapiv1beta1.ScoreCoordinateTypeBuiltIn,
+				BuiltIn: e.Name(),
+			}] = e.Weight()
+		}
+	}
+
+	prioritizerWeights, status := mergeWeights(defaultWeights, config.Prioritizers)
+	if status.IsError() {
+		return nil, status.AsError()
+	}
+
+	scheduler.prioritizerWeights = prioritizerWeights
+	if p, ok := handle.(decisionCacheProvider); ok {
+		scheduler.decisionCache = p.DecisionCache()
+	}
+
+	return scheduler, nil
+}
+
+// ClusterFields returns the fields of ManagedCluster read by the plugins of the scheduler,
+// including the prioritizers which can be enabled by the placements. All the fields are
+// returned if any plugin does not declare the fields it reads.
+func (s *pluginScheduler) ClusterFields() []plugins.ClusterField {
+	// the labels and claims are read to spread the decisions over the topologies
+	fields := sets.NewString(string(plugins.ClusterFieldLabels), string(plugins.ClusterFieldClaims))
+
+	all := []plugins.Plugin{
+		balance.New(s.handle),
+		steady.New(s.handle),
+		tainttoleration.New(s.handle),
+		resource.NewResourcePrioritizerBuilder(s.handle).WithPrioritizerName(PrioritizerResourceAllocatableCPU).Build(),
+		addon.NewAddOnPrioritizerBuilder(s.handle).Build(),
+	}
+	for _, p := range s.filters {
+		all = append(all, p)
+	}
+	for _, p := range s.preFilters {
+		all = append(all, p)
+	}
+	for _, p := range s.postFilters {
+		all = append(all, p)
+	}
+	for _, p := range s.reservers {
+		all = append(all, p)
+	}
+	for _, p := range s.postBinders {
+		all = append(all, p)
+	}
+	for _, p := range s.extenders {
+		all = append(all, p)
+	}
+
+	for _, p := range all {
+		reader, ok := p.(plugins.ClusterFieldsReader)
+		if !ok {
+			return plugins.AllClusterFields
+		}
+		for _, field := range reader.ClusterFields() {
+			fields.Insert(string(field))
+		}
+	}
+
+	result := []plugins.ClusterField{}
+	for _, field := range plugins.AllClusterFields {
+		if fields.Has(string(field)) {
+			result = append(result, field)
+		}
+	}
+	return result
+}
+
+// addPlugin registers the plugin at the PreFilter, PostFilter, Reserve and PostBind extension
+// points it implements.
+func (s *pluginScheduler) addPlugin(plugin plugins.Plugin) {
+	if p, ok := plugin.(plugins.PreFilter); ok {
+		s.preFilters = append(s.preFilters, p)
+	}
+	if p, ok := plugin.(plugins.PostFilter); ok {
+		s.postFilters = append(s.postFilters, p)
+	}
+	if p, ok := plugin.(plugins.Reserve); ok {
+		s.reservers = append(s.reservers, p)
+	}
+	if p, ok := plugin.(plugins.PostBind); ok {
+		s.postBinders = append(s.postBinders, p)
 	}
 }
 
@@ -170,14 +404,34 @@ func (s *pluginScheduler) Schedule(
 
 	results := &scheduleResult{
 		filteredRecords: map[string][]*clusterapiv1.ManagedCluster{},
+		rejectedRecords: map[string]map[string]string{},
 		scoreRecords:    []PrioritizerResult{},
 	}
 
+	// all the plugins see the same decisions of the placements during a scheduling cycle
+	if s.decisionCache != nil {
+		s.cacheLock.Lock()
+		ctx = schedulingcache.WithSnapshot(ctx, s.decisionCache.Snapshot())
+		s.cacheLock.Unlock()
+	}
+
+	// prefilter the placement
+	for _, p := range s.preFilters {
+		status := p.PreFilter(ctx, placement)
+		switch {
+		case status.IsError():
+			return results, status
+		case status.Code() == framework.Warning:
+			klog.Warningf("%v", status.Message())
+			finalStatus = status
+		}
+	}
+
 	// filter clusters
 	filterPipline := []string{}
 
 	for _, f := range s.filters {
-		filterResult, status := f.Filter(ctx, placement, filtered)
+		filterResult, status := s.parallelizer.filter(ctx, f, placement, filtered)
 		filtered = filterResult.Filtered
 
 		switch {
@@ -191,6 +445,32 @@ func (s *pluginScheduler) Schedule(
 		filterPipline = append(filterPipline, f.Name())
 
 		results.filteredRecords[strings.Join(filterPipline, ",")] = filtered
+		if len(filterResult.Rejected) > 0 {
+			results.rejectedRecords[strings.Join(filterPipline, ",")] = filterResult.Rejected
+		}
+	}
+
+	// postfilter clusters if the feasible clusters are not enough
+	for _, p := range s.postFilters {
+		if placement.Spec.NumberOfClusters == nil || len(filtered) >= int(*placement.Spec.NumberOfClusters) {
+			break
+		}
+
+		filterResult, status := p.PostFilter(ctx, placement, clusters, filtered)
+		switch {
+		case status.IsError():
+			return results, status
+		case status.Code() == framework.Warning:
+			klog.Warningf("%v", status.Message())
+			finalStatus = status
+		}
+
+		if filterResult.Filtered == nil {
+			continue
+		}
+		filtered = filterResult.Filtered
+		filterPipline = append(filterPipline, p.Name())
+		results.filteredRecords[strings.Join(filterPipline, ",")] = filtered
 	}
 
 	// Prioritize clusters
@@ -206,7 +486,7 @@ func (s *pluginScheduler) Schedule(
 	}
 
 	// 2. Generate prioritizers for each placement whose weight != 0.
-	prioritizers, status := getPrioritizers(weights, s.handle)
+	prioritizers, status := getPrioritizers(weights, s.handle, s.extenders)
 	switch {
 	case status.IsError():
 		return results, status
@@ -220,10 +500,9 @@ func (s *pluginScheduler) Schedule(
 	for _, cluster := range filtered {
 		scoreSum[cluster.Name] = 0
 	}
-	for sc, p := range prioritizers {
-		// Get cluster score.
-		scoreResult, status := p.Score(ctx, placement, filtered)
-		score := scoreResult.Scores
+	// The prioritizers run concurrently, and the results are handled in a stable order.
+	for _, r := range s.parallelizer.score(ctx, prioritizers, placement, filtered) {
+		sc, p, score, status := r.scoreCoordinate, r.prioritizer, r.scores, r.status
 
 		switch {
 		case status.IsError():
@@ -233,6 +512,24 @@ func (s *pluginScheduler) Schedule(
 			finalStatus = status
 		}
 
+		// Normalize the scores before they are weighted.
+		if n, ok := p.(plugins.ScoreNormalizer); ok {
+			status := n.NormalizeScore(ctx, placement, score)
+			switch {
+			case status.IsError():
+				return results, status
+			case status.Code() == framework.Warning:
+				klog.Warningf("%v", status.Message())
+				finalStatus = status
+			}
+		}
+
+		// Keep the scores in range, so that a prioritizer cannot dominate the others.
+		if status := normalizeScores(p.Name(), normalizationMode(s.scoreNormalization, sc), score); status.Code() == framework.Warning {
+			klog.Warningf("%v", status.Message())
+			finalStatus = status
+		}
+
 		// Record prioritizer score and weight
 		weight := weights[sc]
 		results.scoreRecords = append(results.scoreRecords, PrioritizerResult{Name: p.Name(), Weight: weight, Scores: score})
@@ -246,7 +543,9 @@ func (s *pluginScheduler) Schedule(
 
 	}
 
-	// 4. Sort clusters by score, if score is equal, sort by name
+	// 4. Sort clusters by score, if score is equal, sort by name. The clusters are copied since
+	// the slice may be shared by the filter records, e.g. if a filter filters out nothing.
+	filtered = append([]*clusterapiv1.ManagedCluster{}, filtered...)
 	sort.SliceStable(filtered, func(i, j int) bool {
 		if scoreSum[filtered[i].Name] == scoreSum[filtered[j].Name] {
 			return filtered[i].Name < filtered[j].Name
@@ -259,14 +558,30 @@ func (s *pluginScheduler) Schedule(
 	results.scoreSum = scoreSum
 
 	// select clusters and generate cluster decisions
-	decisions := selectClusters(placement, filtered)
+	decisions, status := selectClusters(placement, filtered)
+	if status.Code() == framework.Warning {
+		klog.Warningf("%v", status.Message())
+		finalStatus = status
+	}
 	scheduled, unscheduled := len(decisions), 0
 	if placement.Spec.NumberOfClusters != nil {
 		unscheduled = int(*placement.Spec.NumberOfClusters) - scheduled
+	} else {
+		unscheduled = len(filtered) - scheduled
+	}
+	if unscheduled < 0 {
+		unscheduled = 0
 	}
 	results.scheduledDecisions = decisions
 	results.unscheduledDecisions = unscheduled
 
+	// explain the decisions
+	existingDecisions := getExistingDecisionClusterNames(ctx, s.handle, placement)
+	for i := range decisions {
+		decisions[i].Reason = newDecisionReason(
+			decisions[i].ClusterName, filterPipline, results.scoreRecords, scoreSum, existingDecisions)
+	}
+
 	// set placement requeue time
 	for _, f := range s.filters {
 		if r, _ := f.RequeueAfter(ctx, placement); r.RequeueTime != nil {
@@ -284,17 +599,85 @@ func (s *pluginScheduler) Schedule(
 	return results, finalStatus
 }
 
-// makeClusterDecisions selects clusters based on given cluster slice and then creates
-// cluster decisions.
-func selectClusters(placement *clusterapiv1beta1.Placement, clusters []*clusterapiv1.ManagedCluster) []clusterapiv1beta1.ClusterDecision {
+func (s *pluginScheduler) Reserve(
+	ctx context.Context,
+	placement *clusterapiv1beta1.Placement,
+	decisions []clusterapiv1beta1.ClusterDecision,
+) *framework.Status {
+	s.cacheLock.Lock()
+	defer s.cacheLock.Unlock()
+
+	if s.decisionCache != nil {
+		s.decisionCache.Assume(placement, decisions)
+	}
+
+	for i, r := range s.reservers {
+		status := r.Reserve(ctx, placement, decisions)
+		if status.IsError() {
+			// unreserve the failed plugin as well since it may have reserved partially
+			for j := i; j >= 0; j-- {
+				s.reservers[j].Unreserve(ctx, placement, decisions)
+			}
+			if s.decisionCache != nil {
+				s.decisionCache.Forget(placement)
+			}
+			return status
+		}
+		if status.Code() == framework.Warning {
+			klog.Warningf("%v", status.Message())
+		}
+	}
+	return framework.NewStatus("", framework.Success, "")
+}
+
+func (s *pluginScheduler) Unreserve(
+	ctx context.Context,
+	placement *clusterapiv1beta1.Placement,
+	decisions []clusterapiv1beta1.ClusterDecision,
+) {
+	s.cacheLock.Lock()
+	defer s.cacheLock.Unlock()
+
+	for i := len(s.reservers) - 1; i >= 0; i-- {
+		s.reservers[i].Unreserve(ctx, placement, decisions)
+	}
+	if s.decisionCache != nil {
+		s.decisionCache.Forget(placement)
+	}
+}
+
+func (s *pluginScheduler) PostBind(
+	ctx context.Context,
+	placement *clusterapiv1beta1.Placement,
+	decisions []clusterapiv1beta1.ClusterDecision,
+) {
+	for _, p := range s.postBinders {
+		if status := p.PostBind(ctx, placement, decisions); !status.IsSuccess() {
+			klog.Warningf("PostBind of plugin %s failed for placement %s/%s: %s",
+				p.Name(), placement.Namespace, placement.Name, status.Message())
+		}
+	}
+}
+
+// selectClusters selects clusters based on given cluster slice and then creates
+// cluster decisions. The clusters are distributed among topologies if spread constraints
+// are defined in the placement.
+func selectClusters(placement *clusterapiv1beta1.Placement, clusters []*clusterapiv1.ManagedCluster) ([]clusterapiv1beta1.ClusterDecision, *framework.Status) {
 	numOfDecisions := len(clusters)
 	if placement.Spec.NumberOfClusters != nil {
 		numOfDecisions = int(*placement.Spec.NumberOfClusters)
 	}
+	// the placements are validated by the API server, but not the ones of the debugger
+	if numOfDecisions < 0 {
+		numOfDecisions = 0
+	}
 
-	// truncate the cluster slice if the desired number of decisions is less than
-	// the number of the candidate clusters
-	if numOfDecisions < len(clusters) {
+	status := framework.NewStatus("", framework.Success, "")
+	if len(placement.Spec.SpreadPolicy.SpreadConstraints) != 0 {
+		clusters, status = selectClustersWithSpreadPolicy(placement, clusters, numOfDecisions)
+	} else if numOfDecisions < len(clusters) {
+		// truncate the cluster slice if the desired number of decisions is less than
+		// the number of the candidate clusters
 		clusters = clusters[:numOfDecisions]
 	}
 
@@ -304,7 +687,48 @@ func selectClusters(placement *clusterapiv1beta1.Placement, clusters []*clustera
 			ClusterName: cluster.Name,
 		})
 	}
-	return decisions
+	return decisions, status
+}
+
+// newDecisionReason returns the JSON encoded DecisionReason of the given cluster.
+func newDecisionReason(
+	clusterName string,
+	filters []string,
+	scoreRecords []PrioritizerResult,
+	scoreSum PrioritizerScore,
+	existingDecisions sets.String,
+) string {
+	reason := DecisionReason{
+		Filters:   filters,
+		Score:     scoreSum[clusterName],
+		Selection: SelectionNew,
+	}
+	if reason.Filters == nil {
+		reason.Filters = []string{}
+	}
+	for _, record := range scoreRecords {
+		if reason.Prioritizers == nil {
+			reason.Prioritizers = map[string]int64{}
+		}
+		reason.Prioritizers[record.Name] = record.Scores[clusterName] * int64(record.Weight)
+	}
+	if existingDecisions.Has(clusterName) {
+		reason.Selection = SelectionSteady
+	}
+
+	// json.Marshal sorts the map keys, so the reason is stable for the same result
+	reasonBytes, err := json.Marshal(reason)
+	if err != nil {
+		klog.Warningf("Failed to encode the decision reason of cluster %s: %v", clusterName, err)
+		return ""
+	}
+	return string(reasonBytes)
+}
+
+// getExistingDecisionClusterNames returns the names of clusters selected by the existing
+// placementdecisions of the placement.
+func getExistingDecisionClusterNames(ctx context.Context, handle plugins.Handle, placement *clusterapiv1beta1.Placement) sets.String {
+	return handle.Snapshot(ctx).PlacementClusters(placement.Namespace, placement.Name)
 }
 
 // setRequeueAfter selects minimal time.Duration as requeue time
@@ -355,8 +779,13 @@ func mergeWeights(defaultWeight map[clusterapiv1beta1.ScoreCoordinate]int32, cus
 	return weights, status
 }
 
-// Generate prioritizers for the placement.
-func getPrioritizers(weights map[clusterapiv1beta1.ScoreCoordinate]int32, handle plugins.Handle) (map[clusterapiv1beta1.ScoreCoordinate]plugins.Prioritizer, *framework.Status) {
+// Generate prioritizers for the placement. The builtIn prioritizers which are not in-tree are
+// looked up in the given extenders by name.
+func getPrioritizers(
+	weights map[clusterapiv1beta1.ScoreCoordinate]int32,
+	handle plugins.Handle,
+	extenders map[string]plugins.Prioritizer,
+) (map[clusterapiv1beta1.ScoreCoordinate]plugins.Prioritizer, *framework.Status) {
 	result := make(map[clusterapiv1beta1.ScoreCoordinate]plugins.Prioritizer)
 	status := framework.NewStatus("", framework.Success, "")
 	for k, v := range weights {
@@ -369,8 +798,12 @@ func getPrioritizers(weights map[clusterapiv1beta1.ScoreCoordinate]int32, handle
 				result[k] = balance.New(handle)
 			case k.BuiltIn == PrioritizerSteady:
 				result[k] = steady.New(handle)
-			case k.BuiltIn == PrioritizerResourceAllocatableCPU || k.BuiltIn == PrioritizerResourceAllocatableMemory:
+			case k.BuiltIn == PrioritizerTaintToleration:
+				result[k] = tainttoleration.New(handle)
+			case resource.IsResourcePrioritizer(k.BuiltIn):
 				result[k] = resource.NewResourcePrioritizerBuilder(handle).WithPrioritizerName(k.BuiltIn).Build()
+			case extenders[k.BuiltIn] != nil:
+				result[k] = extenders[k.BuiltIn]
 			default:
 				msg := fmt.Sprintf("incorrect builtin prioritizer: %s", k.BuiltIn)
 				return nil, framework.NewStatus("", framework.Misconfigured, msg)
@@ -399,7 +832,7 @@ func (r *scheduleResult) FilterResults() []FilterResult {
 
 	// go through the FilterResults by key length
 	for _, name := range filteredRecordsKey {
-		result := FilterResult{Name: name, FilteredClusters: []string{}}
+		result := FilterResult{Name: name, FilteredClusters: []string{}, RejectedClusters: r.rejectedRecords[name]}
 
 		for _, c := range r.filteredRecords[name] {
 			result.FilteredClusters = append(result.FilteredClusters, c.Name)
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"reflect"
 	"sort"
+	"strings"
 	"testing"
 
 	"k8s.io/apimachinery/pkg/runtime"
@@ -16,7 +17,10 @@ import (
 	clusterapiv1beta1 "open-cluster-management.io/api/cluster/v1beta1"
 	clusterlisterv1beta1 "open-cluster-management.io/api/cluster/v1beta1"
 	"open-cluster-management.io/placement/pkg/controllers/framework"
+	schedulingcache "open-cluster-management.io/placement/pkg/controllers/scheduling/cache"
 	testinghelpers "open-cluster-management.io/placement/pkg/helpers/testing"
+	"open-cluster-management.io/placement/pkg/plugins"
+	"open-cluster-management.io/placement/pkg/plugins/kubernetesversion"
 )
 
 func TestSchedule(t *testing.T) {
@@ -56,6 +60,14 @@ func TestSchedule(t *testing.T) {
 					Name:             "Predicate,TaintToleration",
 					FilteredClusters: []string{"cluster1"},
 				},
+				{
+					Name:             "Predicate,TaintToleration,KubernetesVersion",
+					FilteredClusters: []string{"cluster1"},
+				},
+				{
+					Name:             "Predicate,TaintToleration,KubernetesVersion,CELPredicate",
+					FilteredClusters: []string{"cluster1"},
+				},
 			},
 			expectedScoreResult: []PrioritizerResult{
 				{
@@ -68,6 +80,11 @@ func TestSchedule(t *testing.T) {
 					Weight: 1,
 					Scores: PrioritizerScore{"cluster1": 0},
 				},
+				{
+					Name:   "TaintToleration",
+					Weight: 1,
+					Scores: PrioritizerScore{"cluster1": 0},
+				},
 			},
 			clusters: []*clusterapiv1.ManagedCluster{
 				testinghelpers.NewManagedCluster("cluster1").WithLabel(clusterSetLabel, clusterSetName).Build(),
@@ -98,6 +115,14 @@ func TestSchedule(t *testing.T) {
 					Name:             "Predicate,TaintToleration",
 					FilteredClusters: []string{"cluster1"},
 				},
+				{
+					Name:             "Predicate,TaintToleration,KubernetesVersion",
+					FilteredClusters: []string{"cluster1"},
+				},
+				{
+					Name:             "Predicate,TaintToleration,KubernetesVersion,CELPredicate",
+					FilteredClusters: []string{"cluster1"},
+				},
 			},
 			expectedScoreResult: []PrioritizerResult{
 				{
@@ -110,6 +135,11 @@ func TestSchedule(t *testing.T) {
 					Weight: 1,
 					Scores: PrioritizerScore{"cluster1": 0},
 				},
+				{
+					Name:   "TaintToleration",
+					Weight: 1,
+					Scores: PrioritizerScore{"cluster1": 0},
+				},
 			},
 			expectedUnScheduled: 2,
 			expectedStatus:      *framework.NewStatus("", framework.Success, ""),
@@ -177,6 +207,14 @@ func TestSchedule(t *testing.T) {
 					Name:             "Predicate,TaintToleration",
 					FilteredClusters: []string{"cluster1", "cluster2", "cluster3"},
 				},
+				{
+					Name:             "Predicate,TaintToleration,KubernetesVersion",
+					FilteredClusters: []string{"cluster1", "cluster2", "cluster3"},
+				},
+				{
+					Name:             "Predicate,TaintToleration,KubernetesVersion,CELPredicate",
+					FilteredClusters: []string{"cluster1", "cluster2", "cluster3"},
+				},
 			},
 			expectedScoreResult: []PrioritizerResult{
 				{
@@ -189,6 +227,11 @@ func TestSchedule(t *testing.T) {
 					Weight: 1,
 					Scores: PrioritizerScore{"cluster1": 100, "cluster2": 100, "cluster3": 0},
 				},
+				{
+					Name:   "TaintToleration",
+					Weight: 1,
+					Scores: PrioritizerScore{"cluster1": 0, "cluster2": 0, "cluster3": 0},
+				},
 			},
 			expectedUnScheduled: 0,
 			expectedStatus:      *framework.NewStatus("", framework.Success, ""),
@@ -213,6 +256,14 @@ func TestSchedule(t *testing.T) {
 					Name:             "Predicate,TaintToleration",
 					FilteredClusters: []string{"cluster1"},
 				},
+				{
+					Name:             "Predicate,TaintToleration,KubernetesVersion",
+					FilteredClusters: []string{"cluster1"},
+				},
+				{
+					Name:             "Predicate,TaintToleration,KubernetesVersion,CELPredicate",
+					FilteredClusters: []string{"cluster1"},
+				},
 			},
 			expectedScoreResult: []PrioritizerResult{
 				{
@@ -225,6 +276,11 @@ func TestSchedule(t *testing.T) {
 					Weight: 1,
 					Scores: PrioritizerScore{"cluster1": 0},
 				},
+				{
+					Name:   "TaintToleration",
+					Weight: 1,
+					Scores: PrioritizerScore{"cluster1": 0},
+				},
 			},
 			clusters: []*clusterapiv1.ManagedCluster{
 				testinghelpers.NewManagedCluster("cluster1").WithLabel(clusterSetLabel, clusterSetName).Build(),
@@ -278,6 +334,14 @@ func TestSchedule(t *testing.T) {
 					Name:             "Predicate,TaintToleration",
 					FilteredClusters: []string{"cluster1", "cluster3"},
 				},
+				{
+					Name:             "Predicate,TaintToleration,KubernetesVersion",
+					FilteredClusters: []string{"cluster1", "cluster3"},
+				},
+				{
+					Name:             "Predicate,TaintToleration,KubernetesVersion,CELPredicate",
+					FilteredClusters: []string{"cluster1", "cluster3"},
+				},
 			},
 			expectedScoreResult: []PrioritizerResult{
 				{
@@ -290,6 +354,11 @@ func TestSchedule(t *testing.T) {
 					Weight: 1,
 					Scores: PrioritizerScore{"cluster1": 0, "cluster3": 0},
 				},
+				{
+					Name:   "TaintToleration",
+					Weight: 1,
+					Scores: PrioritizerScore{"cluster1": 0, "cluster3": 0},
+				},
 			},
 			expectedUnScheduled: 1,
 			expectedStatus:      *framework.NewStatus("", framework.Success, ""),
@@ -323,6 +392,14 @@ func TestSchedule(t *testing.T) {
 					Name:             "Predicate,TaintToleration",
 					FilteredClusters: []string{"cluster1", "cluster2", "cluster3"},
 				},
+				{
+					Name:             "Predicate,TaintToleration,KubernetesVersion",
+					FilteredClusters: []string{"cluster1", "cluster2", "cluster3"},
+				},
+				{
+					Name:             "Predicate,TaintToleration,KubernetesVersion,CELPredicate",
+					FilteredClusters: []string{"cluster1", "cluster2", "cluster3"},
+				},
 			},
 			expectedScoreResult: []PrioritizerResult{
 				{
@@ -345,6 +422,11 @@ func TestSchedule(t *testing.T) {
 					Weight: 1,
 					Scores: PrioritizerScore{"cluster1": 30, "cluster2": 40, "cluster3": 50},
 				},
+				{
+					Name:   "TaintToleration",
+					Weight: 1,
+					Scores: PrioritizerScore{"cluster1": 0, "cluster2": 0, "cluster3": 0},
+				},
 			},
 			expectedUnScheduled: 0,
 			expectedStatus:      *framework.NewStatus("", framework.Success, ""),
@@ -375,6 +457,14 @@ func TestSchedule(t *testing.T) {
 					Name:             "Predicate,TaintToleration",
 					FilteredClusters: []string{"cluster1", "cluster2", "cluster3"},
 				},
+				{
+					Name:             "Predicate,TaintToleration,KubernetesVersion",
+					FilteredClusters: []string{"cluster1", "cluster2", "cluster3"},
+				},
+				{
+					Name:             "Predicate,TaintToleration,KubernetesVersion,CELPredicate",
+					FilteredClusters: []string{"cluster1", "cluster2", "cluster3"},
+				},
 			},
 			expectedScoreResult: []PrioritizerResult{
 				{
@@ -423,6 +513,14 @@ func TestSchedule(t *testing.T) {
 					Name:             "Predicate,TaintToleration",
 					FilteredClusters: []string{"cluster1", "cluster2"},
 				},
+				{
+					Name:             "Predicate,TaintToleration,KubernetesVersion",
+					FilteredClusters: []string{"cluster1", "cluster2"},
+				},
+				{
+					Name:             "Predicate,TaintToleration,KubernetesVersion,CELPredicate",
+					FilteredClusters: []string{"cluster1", "cluster2"},
+				},
 			},
 			expectedScoreResult: []PrioritizerResult{
 				{
@@ -435,6 +533,11 @@ func TestSchedule(t *testing.T) {
 					Weight: 1,
 					Scores: PrioritizerScore{"cluster1": 100, "cluster2": 0},
 				},
+				{
+					Name:   "TaintToleration",
+					Weight: 1,
+					Scores: PrioritizerScore{"cluster1": 0, "cluster2": 0},
+				},
 			},
 			expectedUnScheduled: 2,
 			expectedStatus:      *framework.NewStatus("", framework.Success, ""),
@@ -467,7 +570,15 @@ func TestSchedule(t *testing.T) {
 				},
 				{
 					Name:             "Predicate,TaintToleration",
-					FilteredClusters: []string{"cluster3", "cluster1", "cluster2"},
+					FilteredClusters: []string{"cluster1", "cluster2", "cluster3"},
+				},
+				{
+					Name:             "Predicate,TaintToleration,KubernetesVersion",
+					FilteredClusters: []string{"cluster1", "cluster2", "cluster3"},
+				},
+				{
+					Name:             "Predicate,TaintToleration,KubernetesVersion,CELPredicate",
+					FilteredClusters: []string{"cluster1", "cluster2", "cluster3"},
 				},
 			},
 			expectedScoreResult: []PrioritizerResult{
@@ -481,6 +592,11 @@ func TestSchedule(t *testing.T) {
 					Weight: 1,
 					Scores: PrioritizerScore{"cluster1": 0, "cluster2": 0, "cluster3": 0},
 				},
+				{
+					Name:   "TaintToleration",
+					Weight: 1,
+					Scores: PrioritizerScore{"cluster1": 0, "cluster2": 0, "cluster3": 0},
+				},
 			},
 			expectedUnScheduled: 0,
 			expectedStatus:      *framework.NewStatus("", framework.Success, ""),
@@ -523,7 +639,15 @@ func TestSchedule(t *testing.T) {
 				},
 				{
 					Name:             "Predicate,TaintToleration",
-					FilteredClusters: []string{"cluster3", "cluster1", "cluster2"},
+					FilteredClusters: []string{"cluster1", "cluster2", "cluster3"},
+				},
+				{
+					Name:             "Predicate,TaintToleration,KubernetesVersion",
+					FilteredClusters: []string{"cluster1", "cluster2", "cluster3"},
+				},
+				{
+					Name:             "Predicate,TaintToleration,KubernetesVersion,CELPredicate",
+					FilteredClusters: []string{"cluster1", "cluster2", "cluster3"},
 				},
 			},
 			expectedScoreResult: []PrioritizerResult{
@@ -537,6 +661,11 @@ func TestSchedule(t *testing.T) {
 					Weight: 1,
 					Scores: PrioritizerScore{"cluster1": 0, "cluster2": 0, "cluster3": 100},
 				},
+				{
+					Name:   "TaintToleration",
+					Weight: 1,
+					Scores: PrioritizerScore{"cluster1": 0, "cluster2": 0, "cluster3": 0},
+				},
 			},
 			expectedUnScheduled: 0,
 			expectedStatus:      *framework.NewStatus("", framework.Success, ""),
@@ -557,8 +686,13 @@ func TestSchedule(t *testing.T) {
 			if status.Message() != c.expectedStatus.Message() && status.Code() != c.expectedStatus.Code() {
 				t.Errorf("unexpected err: %v", status.AsError())
 			}
-			if len(c.expectedDecisions) != 0 && !reflect.DeepEqual(result.Decisions(), c.expectedDecisions) {
-				t.Errorf("expected %v scheduled, but got %v", c.expectedDecisions, result.Decisions())
+			// the reasons of decisions are verified in TestDecisionReason
+			decisions := []clusterapiv1beta1.ClusterDecision{}
+			for _, d := range result.Decisions() {
+				decisions = append(decisions, clusterapiv1beta1.ClusterDecision{ClusterName: d.ClusterName})
+			}
+			if len(c.expectedDecisions) != 0 && !reflect.DeepEqual(decisions, c.expectedDecisions) {
+				t.Errorf("expected %v scheduled, but got %v", c.expectedDecisions, decisions)
 			}
 			if result.NumOfUnscheduled() != c.expectedUnScheduled {
 				t.Errorf("expected %d unscheduled, but got %d", c.expectedUnScheduled, result.NumOfUnscheduled())
@@ -588,10 +722,535 @@ func TestSchedule(t *testing.T) {
 	}
 }
 
-func placementDecisionName(placementName string, index int) string {
-	return fmt.Sprintf("%s-decision-%d", placementName, index)
+func TestDecisionReason(t *testing.T) {
+	clusterSetName := "clusterSets"
+	placementNamespace := "ns1"
+	placementName := "placement1"
+
+	placement := testinghelpers.NewPlacement(placementNamespace, placementName).WithNOC(2).Build()
+	initObjs := []runtime.Object{
+		placement,
+		testinghelpers.NewPlacementDecision(placementNamespace, placementDecisionName(placementName, 1)).
+			WithLabel(placementLabel, placementName).
+			WithDecisions("cluster1").Build(),
+		testinghelpers.NewPlacementDecision(placementNamespace, placementDecisionName("others", 1)).
+			WithDecisions("cluster3").Build(),
+	}
+	clusters := []*clusterapiv1.ManagedCluster{
+		testinghelpers.NewManagedCluster("cluster1").WithLabel(clusterSetLabel, clusterSetName).Build(),
+		testinghelpers.NewManagedCluster("cluster2").WithLabel(clusterSetLabel, clusterSetName).Build(),
+		testinghelpers.NewManagedCluster("cluster3").WithLabel(clusterSetLabel, clusterSetName).Build(),
+	}
+
+	clusterClient := clusterfake.NewSimpleClientset(initObjs...)
+	s := NewPluginScheduler(testinghelpers.NewFakePluginHandle(t, clusterClient, initObjs...))
+	result, status := s.Schedule(context.TODO(), placement, clusters)
+	if status.IsError() {
+		t.Fatalf("unexpected err: %v", status.AsError())
+	}
+
+	expectedReasons := map[string]DecisionReason{
+		"cluster1": {
+			Filters:      []string{"Predicate", "TaintToleration", "KubernetesVersion", "CELPredicate"},
+			Score:        200,
+			Prioritizers: map[string]int64{"Balance": 100, "Steady": 100, "TaintToleration": 0},
+			Selection:    SelectionSteady,
+		},
+		"cluster2": {
+			Filters:      []string{"Predicate", "TaintToleration", "KubernetesVersion", "CELPredicate"},
+			Score:        100,
+			Prioritizers: map[string]int64{"Balance": 100, "Steady": 0, "TaintToleration": 0},
+			Selection:    SelectionNew,
+		},
+	}
+	if len(result.Decisions()) != len(expectedReasons) {
+		t.Fatalf("expected %d decisions, but got %v", len(expectedReasons), result.Decisions())
+	}
+	for _, decision := range result.Decisions() {
+		reason := DecisionReason{}
+		if err := json.Unmarshal([]byte(decision.Reason), &reason); err != nil {
+			t.Fatalf("unexpected err: %v", err)
+		}
+		if !reflect.DeepEqual(reason, expectedReasons[decision.ClusterName]) {
+			t.Errorf("expected reason %v of cluster %s, but got %v", expectedReasons[decision.ClusterName], decision.ClusterName, reason)
+		}
+	}
+}
+
+// fakeExtensionPlugin implements all the extension points and records the calls.
+type fakeExtensionPlugin struct {
+	name          string
+	calls         *[]string
+	preFilterCode framework.Code
+	reserveCode   framework.Code
+}
+
+func (p *fakeExtensionPlugin) Name() string {
+	return p.name
+}
+
+func (p *fakeExtensionPlugin) Description() string {
+	return "fake"
+}
+
+func (p *fakeExtensionPlugin) RequeueAfter(ctx context.Context, placement *clusterapiv1beta1.Placement) (plugins.PluginRequeueResult, *framework.Status) {
+	return plugins.PluginRequeueResult{}, framework.NewStatus(p.name, framework.Success, "")
+}
+
+func (p *fakeExtensionPlugin) PreFilter(ctx context.Context, placement *clusterapiv1beta1.Placement) *framework.Status {
+	*p.calls = append(*p.calls, p.name+":PreFilter")
+	return framework.NewStatus(p.name, p.preFilterCode, "prefilter")
+}
+
+// PostFilter makes all the clusters feasible.
+func (p *fakeExtensionPlugin) PostFilter(ctx context.Context, placement *clusterapiv1beta1.Placement,
+	clusters, feasible []*clusterapiv1.ManagedCluster) (plugins.PluginFilterResult, *framework.Status) {
+	*p.calls = append(*p.calls, p.name+":PostFilter")
+	return plugins.PluginFilterResult{Filtered: clusters}, framework.NewStatus(p.name, framework.Success, "")
+}
+
+// Score gives out of range scores, which are clamped by NormalizeScore.
+func (p *fakeExtensionPlugin) Score(ctx context.Context, placement *clusterapiv1beta1.Placement,
+	clusters []*clusterapiv1.ManagedCluster) (plugins.PluginScoreResult, *framework.Status) {
+	*p.calls = append(*p.calls, p.name+":Score")
+	scores := map[string]int64{}
+	for i, cluster := range clusters {
+		scores[cluster.Name] = int64(1000 * i)
+	}
+	return plugins.PluginScoreResult{Scores: scores}, framework.NewStatus(p.name, framework.Success, "")
+}
+
+func (p *fakeExtensionPlugin) NormalizeScore(ctx context.Context, placement *clusterapiv1beta1.Placement, scores map[string]int64) *framework.Status {
+	*p.calls = append(*p.calls, p.name+":NormalizeScore")
+	for name, score := range scores {
+		if score > plugins.MaxClusterScore {
+			scores[name] = plugins.MaxClusterScore
+		}
+	}
+	return framework.NewStatus(p.name, framework.Success, "")
+}
+
+func (p *fakeExtensionPlugin) Reserve(ctx context.Context, placement *clusterapiv1beta1.Placement, decisions []clusterapiv1beta1.ClusterDecision) *framework.Status {
+	*p.calls = append(*p.calls, p.name+":Reserve")
+	return framework.NewStatus(p.name, p.reserveCode, "reserve")
+}
+
+func (p *fakeExtensionPlugin) Unreserve(ctx context.Context, placement *clusterapiv1beta1.Placement, decisions []clusterapiv1beta1.ClusterDecision) {
+	*p.calls = append(*p.calls, p.name+":Unreserve")
+}
+
+func (p *fakeExtensionPlugin) PostBind(ctx context.Context, placement *clusterapiv1beta1.Placement, decisions []clusterapiv1beta1.ClusterDecision) *framework.Status {
+	*p.calls = append(*p.calls, p.name+":PostBind")
+	return framework.NewStatus(p.name, framework.Success, "")
+}
+
+func TestExtensionPoints(t *testing.T) {
+	placementNamespace := "ns1"
+	placementName := "placement1"
+
+	clusters := []*clusterapiv1.ManagedCluster{
+		testinghelpers.NewManagedCluster("cluster1").Build(),
+		testinghelpers.NewManagedCluster("cluster2").Build(),
+		testinghelpers.NewManagedCluster("cluster3").WithTaint(&clusterapiv1.Taint{
+			Key:    "key",
+			Effect: clusterapiv1.TaintEffectNoSelect,
+		}).Build(),
+	}
+
+	cases := []struct {
+		name              string
+		placement         *clusterapiv1beta1.Placement
+		preFilterCode     framework.Code
+		expectedCode      framework.Code
+		expectedCalls     []string
+		expectedFilters   []string
+		expectedDecisions []string
+	}{
+		{
+			name:          "prefilter fails",
+			placement:     testinghelpers.NewPlacement(placementNamespace, placementName).Build(),
+			preFilterCode: framework.Misconfigured,
+			expectedCode:  framework.Misconfigured,
+			expectedCalls: []string{"Fake:PreFilter"},
+		},
+		{
+			name: "enough feasible clusters",
+			placement: testinghelpers.NewPlacement(placementNamespace, placementName).WithNOC(2).
+				WithPrioritizerPolicy(clusterapiv1beta1.PrioritizerPolicyModeExact).WithPrioritizerConfig("Fake", 1).Build(),
+			preFilterCode:     framework.Success,
+			expectedCode:      framework.Success,
+			expectedCalls:     []string{"Fake:PreFilter", "Fake:Score", "Fake:NormalizeScore"},
+			expectedFilters:   []string{"Predicate", "Predicate,TaintToleration", "Predicate,TaintToleration,KubernetesVersion", "Predicate,TaintToleration,KubernetesVersion,CELPredicate"},
+			expectedDecisions: []string{"cluster2", "cluster1"},
+		},
+		{
+			name: "postfilter when feasible clusters are not enough",
+			placement: testinghelpers.NewPlacement(placementNamespace, placementName).WithNOC(3).
+				WithPrioritizerPolicy(clusterapiv1beta1.PrioritizerPolicyModeExact).WithPrioritizerConfig("Fake", 1).Build(),
+			preFilterCode:     framework.Warning,
+			expectedCode:      framework.Warning,
+			expectedCalls:     []string{"Fake:PreFilter", "Fake:PostFilter", "Fake:Score", "Fake:NormalizeScore"},
+			expectedFilters:   []string{"Predicate", "Predicate,TaintToleration", "Predicate,TaintToleration,KubernetesVersion", "Predicate,TaintToleration,KubernetesVersion,CELPredicate", "Predicate,TaintToleration,KubernetesVersion,CELPredicate,Fake"},
+			expectedDecisions: []string{"cluster2", "cluster3", "cluster1"},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			calls := []string{}
+			fake := &fakeExtensionPlugin{name: "Fake", calls: &calls, preFilterCode: c.preFilterCode}
+
+			s := NewPluginScheduler(testinghelpers.NewFakePluginHandle(t, clusterfake.NewSimpleClientset()))
+			s.addPlugin(fake)
+			s.extenders["Fake"] = fake
+
+			result, status := s.Schedule(context.TODO(), c.placement, clusters)
+			if status.Code() != c.expectedCode {
+				t.Errorf("expected status code %v, but got %v: %s", c.expectedCode, status.Code(), status.Message())
+			}
+			if !reflect.DeepEqual(calls, c.expectedCalls) {
+				t.Errorf("expected calls %v, but got %v", c.expectedCalls, calls)
+			}
+			if c.expectedCode == framework.Misconfigured {
+				return
+			}
+
+			filters := []string{}
+			for _, r := range result.FilterResults() {
+				filters = append(filters, r.Name)
+			}
+			if !reflect.DeepEqual(filters, c.expectedFilters) {
+				t.Errorf("expected filter results %v, but got %v", c.expectedFilters, filters)
+			}
+			decisions := []string{}
+			for _, d := range result.Decisions() {
+				decisions = append(decisions, d.ClusterName)
+			}
+			if !reflect.DeepEqual(decisions, c.expectedDecisions) {
+				t.Errorf("expected decisions %v, but got %v", c.expectedDecisions, decisions)
+			}
+			for _, score := range result.PrioritizerScores() {
+				if score > plugins.MaxClusterScore {
+					t.Errorf("expected normalized scores, but got %v", result.PrioritizerScores())
+				}
+			}
+		})
+	}
+}
+
+func TestClusterFields(t *testing.T) {
+	withHealth := NewDefaultSchedulerConfiguration()
+	withHealth.Filters = PluginSet{Enabled: []Plugin{{Name: FilterClusterHealth}}}
+
+	cases := []struct {
+		name           string
+		config         *SchedulerConfiguration
+		plugin         plugins.Plugin
+		expectedFields []plugins.ClusterField
+	}{
+		{
+			name:   "default plugins",
+			config: NewDefaultSchedulerConfiguration(),
+			expectedFields: []plugins.ClusterField{
+				plugins.ClusterFieldLabels,
+				plugins.ClusterFieldClaims,
+				plugins.ClusterFieldTaints,
+				plugins.ClusterFieldResources,
+				plugins.ClusterFieldVersion,
+				plugins.ClusterFieldConditions,
+			},
+		},
+		{
+			name:           "cluster health filter",
+			config:         withHealth,
+			expectedFields: plugins.AllClusterFields,
+		},
+		{
+			name:           "plugin not declaring the fields",
+			config:         NewDefaultSchedulerConfiguration(),
+			plugin:         &fakeExtensionPlugin{name: "Fake", calls: &[]string{}},
+			expectedFields: plugins.AllClusterFields,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			s, err := NewPluginSchedulerWithConfig(testinghelpers.NewFakePluginHandle(t, clusterfake.NewSimpleClientset()), c.config)
+			if err != nil {
+				t.Fatalf("unexpected err: %v", err)
+			}
+			if c.plugin != nil {
+				s.addPlugin(c.plugin)
+			}
+
+			fields := s.ClusterFields()
+			if !reflect.DeepEqual(fields, c.expectedFields) {
+				t.Errorf("expected fields %v, but got %v", c.expectedFields, fields)
+			}
+		})
+	}
+}
+
+func TestReserve(t *testing.T) {
+	placement := testinghelpers.NewPlacement("ns1", "placement1").Build()
+	decisions := []clusterapiv1beta1.ClusterDecision{{ClusterName: "cluster1"}}
+
+	cases := []struct {
+		name          string
+		reserveCodes  []framework.Code
+		expectedCode  framework.Code
+		expectedCalls []string
+	}{
+		{
+			name:          "all reserved",
+			reserveCodes:  []framework.Code{framework.Success, framework.Warning},
+			expectedCode:  framework.Success,
+			expectedCalls: []string{"p0:Reserve", "p1:Reserve"},
+		},
+		{
+			name:          "unreserve when reserve fails",
+			reserveCodes:  []framework.Code{framework.Success, framework.Error, framework.Success},
+			expectedCode:  framework.Error,
+			expectedCalls: []string{"p0:Reserve", "p1:Reserve", "p1:Unreserve", "p0:Unreserve"},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			calls := []string{}
+			s := &pluginScheduler{}
+			for i, code := range c.reserveCodes {
+				s.addPlugin(&fakeExtensionPlugin{name: fmt.Sprintf("p%d", i), calls: &calls, reserveCode: code})
+			}
+
+			status := s.Reserve(context.TODO(), placement, decisions)
+			if status.Code() != c.expectedCode {
+				t.Errorf("expected status code %v, but got %v", c.expectedCode, status.Code())
+			}
+			if !reflect.DeepEqual(calls, c.expectedCalls) {
+				t.Errorf("expected calls %v, but got %v", c.expectedCalls, calls)
+			}
+
+			calls = calls[:0]
+			s.PostBind(context.TODO(), placement, decisions)
+			if len(calls) != len(c.reserveCodes) || !strings.HasSuffix(calls[0], ":PostBind") {
+				t.Errorf("expected PostBind of all plugins, but got %v", calls)
+			}
+		})
+	}
 }
 
 func TestFilterResults(t *testing.T) {
 
 }
+
+func TestScheduleWithReservedDecisions(t *testing.T) {
+	clusters := []*clusterapiv1.ManagedCluster{
+		testinghelpers.NewManagedCluster("cluster1").Build(),
+		testinghelpers.NewManagedCluster("cluster2").Build(),
+	}
+	s := NewPluginScheduler(testinghelpers.NewFakePluginHandle(t, clusterfake.NewSimpleClientset()))
+
+	// both placements select cluster1 without reserving the decisions
+	for _, reserve := range []bool{false, true} {
+		expected := []string{"cluster1", "cluster1"}
+		if reserve {
+			expected = []string{"cluster1", "cluster2"}
+		}
+
+		actual := []string{}
+		for _, name := range []string{"placement1", "placement2"} {
+			placement := testinghelpers.NewPlacement("ns1", name).WithNOC(1).Build()
+			result, status := s.Schedule(context.TODO(), placement, clusters)
+			if status.IsError() {
+				t.Fatalf("unexpected err: %v", status.AsError())
+			}
+			for _, d := range result.Decisions() {
+				actual = append(actual, d.ClusterName)
+			}
+
+			if reserve {
+				if status := s.Reserve(context.TODO(), placement, result.Decisions()); status.IsError() {
+					t.Fatalf("unexpected err: %v", status.AsError())
+				}
+			}
+		}
+		if !reflect.DeepEqual(actual, expected) {
+			t.Errorf("expected decisions %v with reserve %v, but got %v", expected, reserve, actual)
+		}
+	}
+}
+
+func TestScheduleWithRejectedClusters(t *testing.T) {
+	placement := testinghelpers.NewPlacementWithAnnotations("ns1", "placement1", map[string]string{
+		kubernetesversion.KubernetesVersionAnnotation: ">=1.26",
+	}).Build()
+
+	clusters := []*clusterapiv1.ManagedCluster{}
+	expectedRejected := map[string]string{}
+	for i := 0; i < 300; i++ {
+		cluster := testinghelpers.NewManagedCluster(fmt.Sprintf("cluster%d", i)).Build()
+		cluster.Status.Version.Kubernetes = "v1.27.0"
+		if i%2 == 0 {
+			cluster.Status.Version.Kubernetes = "v1.25.0"
+			expectedRejected[cluster.Name] = `Kubernetes version "v1.25.0" is not in range ">=1.26"`
+		}
+		clusters = append(clusters, cluster)
+	}
+
+	// the rejected clusters of all the chunks are merged
+	for _, parallelism := range []int32{1, 16} {
+		s := newSchedulerWithParallelism(t, parallelism, nil)
+		result, status := s.Schedule(context.TODO(), placement, clusters)
+		if status.IsError() {
+			t.Fatalf("unexpected err: %v", status.AsError())
+		}
+
+		filterResults := result.FilterResults()
+		kubeVersionResult := filterResults[2]
+		if kubeVersionResult.Name != "Predicate,TaintToleration,KubernetesVersion" || len(kubeVersionResult.FilteredClusters) != 150 {
+			t.Errorf("expected 150 clusters filtered by KubernetesVersion, but got %v", kubeVersionResult)
+		}
+		if !reflect.DeepEqual(kubeVersionResult.RejectedClusters, expectedRejected) {
+			t.Errorf("expected rejected clusters %v, but got %v", expectedRejected, kubeVersionResult.RejectedClusters)
+		}
+		for i, r := range filterResults {
+			if i != 2 && r.RejectedClusters != nil {
+				t.Errorf("expected no rejected clusters of %s, but got %v", r.Name, r.RejectedClusters)
+			}
+		}
+	}
+}
+
+// snapshotRecorder records the snapshots seen by its PreFilter and Score in a scheduling cycle,
+// and changes the decision cache in between.
+type snapshotRecorder struct {
+	handle    *testinghelpers.FakePluginHandle
+	snapshots []*schedulingcache.Snapshot
+}
+
+func (p *snapshotRecorder) Name() string {
+	return "SnapshotRecorder"
+}
+
+func (p *snapshotRecorder) Description() string {
+	return "snapshot recorder"
+}
+
+func (p *snapshotRecorder) RequeueAfter(ctx context.Context, placement *clusterapiv1beta1.Placement) (plugins.PluginRequeueResult, *framework.Status) {
+	return plugins.PluginRequeueResult{}, framework.NewStatus(p.Name(), framework.Success, "")
+}
+
+func (p *snapshotRecorder) PreFilter(ctx context.Context, placement *clusterapiv1beta1.Placement) *framework.Status {
+	p.snapshots = append(p.snapshots, p.handle.Snapshot(ctx))
+	p.handle.DecisionCache().Assume(testinghelpers.NewPlacement("ns1", "placement2").Build(),
+		[]clusterapiv1beta1.ClusterDecision{{ClusterName: "cluster1"}})
+	return framework.NewStatus(p.Name(), framework.Success, "")
+}
+
+func (p *snapshotRecorder) Score(ctx context.Context, placement *clusterapiv1beta1.Placement,
+	clusters []*clusterapiv1.ManagedCluster) (plugins.PluginScoreResult, *framework.Status) {
+	p.snapshots = append(p.snapshots, p.handle.Snapshot(ctx))
+	return plugins.PluginScoreResult{Scores: map[string]int64{}}, framework.NewStatus(p.Name(), framework.Success, "")
+}
+
+func TestScheduleWithCycleSnapshot(t *testing.T) {
+	clusters := []*clusterapiv1.ManagedCluster{
+		testinghelpers.NewManagedCluster("cluster1").Build(),
+	}
+	placement := testinghelpers.NewPlacement("ns1", "placement1").
+		WithPrioritizerPolicy(clusterapiv1beta1.PrioritizerPolicyModeExact).WithPrioritizerConfig("SnapshotRecorder", 1).Build()
+
+	handle := testinghelpers.NewFakePluginHandle(t, clusterfake.NewSimpleClientset())
+	recorder := &snapshotRecorder{handle: handle}
+	s := NewPluginScheduler(handle)
+	s.addPlugin(recorder)
+	s.extenders[recorder.Name()] = recorder
+
+	for i := 0; i < 2; i++ {
+		if _, status := s.Schedule(context.TODO(), placement, clusters); status.IsError() {
+			t.Fatalf("unexpected err: %v", status.AsError())
+		}
+	}
+	if len(recorder.snapshots) != 4 {
+		t.Fatalf("expected 4 snapshots recorded, but got %d", len(recorder.snapshots))
+	}
+
+	// the plugins see the same snapshot in a cycle, the change in the cache is seen by the next cycle
+	if recorder.snapshots[0] != recorder.snapshots[1] {
+		t.Errorf("expected the same snapshot in the first cycle")
+	}
+	if recorder.snapshots[1].PlacementClusters("ns1", "placement2").Len() != 0 {
+		t.Errorf("expected no decision of placement2 in the first cycle")
+	}
+	if recorder.snapshots[2] != recorder.snapshots[3] || recorder.snapshots[2] == recorder.snapshots[1] {
+		t.Errorf("expected a new snapshot in the second cycle")
+	}
+	if !recorder.snapshots[3].PlacementClusters("ns1", "placement2").Has("cluster1") {
+		t.Errorf("expected the assumed decisions of placement2 in the second cycle")
+	}
+}
+
+// blockingScorer blocks the scoring of placement1 until it is released, like a slow extender.
+type blockingScorer struct {
+	started chan struct{}
+	release chan struct{}
+}
+
+func (p *blockingScorer) Name() string {
+	return "BlockingScorer"
+}
+
+func (p *blockingScorer) Description() string {
+	return "blocking scorer"
+}
+
+func (p *blockingScorer) RequeueAfter(ctx context.Context, placement *clusterapiv1beta1.Placement) (plugins.PluginRequeueResult, *framework.Status) {
+	return plugins.PluginRequeueResult{}, framework.NewStatus(p.Name(), framework.Success, "")
+}
+
+func (p *blockingScorer) Score(ctx context.Context, placement *clusterapiv1beta1.Placement,
+	clusters []*clusterapiv1.ManagedCluster) (plugins.PluginScoreResult, *framework.Status) {
+	if placement.Name == "placement1" {
+		close(p.started)
+		<-p.release
+	}
+	return plugins.PluginScoreResult{Scores: map[string]int64{}}, framework.NewStatus(p.Name(), framework.Success, "")
+}
+
+func TestScheduleConcurrently(t *testing.T) {
+	clusters := []*clusterapiv1.ManagedCluster{
+		testinghelpers.NewManagedCluster("cluster1").Build(),
+	}
+	scorer := &blockingScorer{started: make(chan struct{}), release: make(chan struct{})}
+	s := NewPluginScheduler(testinghelpers.NewFakePluginHandle(t, clusterfake.NewSimpleClientset()))
+	s.addPlugin(scorer)
+	s.extenders[scorer.Name()] = scorer
+
+	newPlacement := func(name string) *clusterapiv1beta1.Placement {
+		return testinghelpers.NewPlacement("ns1", name).
+			WithPrioritizerPolicy(clusterapiv1beta1.PrioritizerPolicyModeExact).WithPrioritizerConfig(scorer.Name(), 1).Build()
+	}
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		placement := newPlacement("placement1")
+		result, _ := s.Schedule(context.TODO(), placement, clusters)
+		s.Reserve(context.TODO(), placement, result.Decisions())
+	}()
+	<-scorer.started
+
+	// placement2 is scheduled and reserved while the scoring of placement1 is blocked
+	placement := newPlacement("placement2")
+	result, status := s.Schedule(context.TODO(), placement, clusters)
+	if status.IsError() {
+		t.Fatalf("unexpected err: %v", status.AsError())
+	}
+	if status := s.Reserve(context.TODO(), placement, result.Decisions()); status.IsError() {
+		t.Fatalf("unexpected err: %v", status.AsError())
+	}
+
+	close(scorer.release)
+	<-done
+}
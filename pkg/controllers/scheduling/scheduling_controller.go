@@ -3,16 +3,14 @@ package scheduling
 import (
 	"context"
 	"fmt"
-	"reflect"
-	"sort"
 	"strings"
 	"time"
 
 	"github.com/openshift/library-go/pkg/controller/factory"
 	"github.com/openshift/library-go/pkg/operator/events"
 	errorhelpers "github.com/openshift/library-go/pkg/operator/v1helpers"
+	"go.opentelemetry.io/otel/trace"
 	corev1 "k8s.io/api/core/v1"
-	apiequality "k8s.io/apimachinery/pkg/api/equality"
 	"k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -21,8 +19,10 @@ import (
 	"k8s.io/apimachinery/pkg/selection"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
 	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/wait"
 	cache "k8s.io/client-go/tools/cache"
 	kevents "k8s.io/client-go/tools/events"
+	"k8s.io/client-go/util/workqueue"
 	"k8s.io/klog/v2"
 	clusterclient "open-cluster-management.io/api/client/cluster/clientset/versioned"
 	clusterinformerv1 "open-cluster-management.io/api/client/cluster/informers/externalversions/cluster/v1"
@@ -38,6 +38,8 @@ import (
 	clusterapiv1beta2 "open-cluster-management.io/api/cluster/v1beta2"
 
 	"open-cluster-management.io/placement/pkg/controllers/framework"
+	"open-cluster-management.io/placement/pkg/controllers/metrics"
+	"open-cluster-management.io/placement/pkg/controllers/tracing"
 )
 
 const (
@@ -51,6 +53,9 @@ const (
 
 var ResyncInterval = time.Minute * 5
 
+// queueDepthSamplingPeriod is how often the depth of the queue is recorded in the metrics.
+var queueDepthSamplingPeriod = time.Second * 10
+
 type enqueuePlacementFunc func(namespace, name string)
 
 // schedulingController schedules cluster decisions for Placements
@@ -63,6 +68,9 @@ type schedulingController struct {
 	placementDecisionLister clusterlisterv1beta1.PlacementDecisionLister
 	scheduler               Scheduler
 	recorder                kevents.EventRecorder
+	// decisionsPerPlacementDecision is the max number of decisions in a placementdecision. The
+	// maxNumOfClusterDecisions is used if it is not set.
+	decisionsPerPlacementDecision int
 }
 
 // NewSchedulingController return an instance of schedulingController
@@ -75,11 +83,12 @@ func NewSchedulingController(
 	placementDecisionInformer clusterinformerv1beta1.PlacementDecisionInformer,
 	placementScoreInformer clusterinformerv1alpha1.AddOnPlacementScoreInformer,
 	scheduler Scheduler,
+	decisionsPerPlacementDecision int,
 	recorder events.Recorder, krecorder kevents.EventRecorder,
 ) factory.Controller {
 	syncCtx := factory.NewSyncContext(schedulingControllerName, recorder)
 
-	enQueuer := newEnqueuer(syncCtx.Queue(), clusterInformer, clusterSetInformer, placementInformer, clusterSetBindingInformer)
+	enQueuer := newEnqueuer(syncCtx.Queue(), clusterInformer, clusterSetInformer, placementInformer, clusterSetBindingInformer, placementDecisionInformer)
 
 	// build controller
 	c := &schedulingController{
@@ -91,6 +100,8 @@ func NewSchedulingController(
 		placementDecisionLister: placementDecisionInformer.Lister(),
 		recorder:                krecorder,
 		scheduler:               scheduler,
+
+		decisionsPerPlacementDecision: decisionsPerPlacementDecision,
 	}
 
 	// setup event handler for cluster informer.
@@ -101,6 +112,7 @@ func NewSchedulingController(
 	// placements will be enqueued by the controller anyway when booting.
 	_, err := clusterInformer.Informer().AddEventHandler(&clusterEventHandler{
 		enqueuer: enQueuer,
+		fields:   clusterFieldsOf(scheduler),
 	})
 	if err != nil {
 		utilruntime.HandleError(err)
@@ -152,7 +164,7 @@ func NewSchedulingController(
 		utilruntime.HandleError(err)
 	}
 
-	return factory.New().
+	controller := factory.New().
 		WithSyncContext(syncCtx).
 		WithInformersQueueKeyFunc(func(obj runtime.Object) string {
 			key, _ := cache.MetaNamespaceKeyFunc(obj)
@@ -177,6 +189,22 @@ func NewSchedulingController(
 		WithBareInformers(clusterInformer.Informer(), clusterSetInformer.Informer(), clusterSetBindingInformer.Informer(), placementScoreInformer.Informer()).
 		WithSync(c.sync).
 		ToController(schedulingControllerName, recorder)
+
+	return &queueDepthSampler{Controller: controller, queue: syncCtx.Queue()}
+}
+
+// queueDepthSampler records the depth of the queue of the controller periodically while it runs,
+// so the metric stays current when no placement is synced.
+type queueDepthSampler struct {
+	factory.Controller
+	queue workqueue.RateLimitingInterface
+}
+
+func (s *queueDepthSampler) Run(ctx context.Context, workers int) {
+	go wait.UntilWithContext(ctx, func(context.Context) {
+		metrics.SetQueueDepth(s.queue.Len())
+	}, queueDepthSamplingPeriod)
+	s.Controller.Run(ctx, workers)
 }
 
 func (c *schedulingController) sync(ctx context.Context, syncCtx factory.SyncContext) error {
@@ -186,6 +214,7 @@ func (c *schedulingController) sync(ctx context.Context, syncCtx factory.SyncCon
 	placement, err := c.getPlacement(queueKey)
 	if errors.IsNotFound(err) {
 		// no work if placement is deleted
+		metrics.DeletePlacement(queueKey)
 		return nil
 	}
 	if err != nil {
@@ -211,9 +240,21 @@ func (c *schedulingController) getPlacement(queueKey string) (*clusterapiv1beta1
 	return placement, nil
 }
 
-func (c *schedulingController) syncPlacement(ctx context.Context, syncCtx factory.SyncContext, placement *clusterapiv1beta1.Placement) error {
+func (c *schedulingController) syncPlacement(ctx context.Context, syncCtx factory.SyncContext, placement *clusterapiv1beta1.Placement) (err error) {
+	defer metrics.ObserveSyncDuration(time.Now())
+
+	ctx, span := tracing.Tracer().Start(ctx, "SyncPlacement", trace.WithAttributes(
+		tracing.PlacementNamespaceKey.String(placement.Namespace),
+		tracing.PlacementNameKey.String(placement.Name),
+	))
+	defer func() {
+		tracing.RecordError(span, err)
+		span.End()
+	}()
+
 	// no work if placement is deleting
 	if !placement.DeletionTimestamp.IsZero() {
+		metrics.DeletePlacement(placement.Namespace + "/" + placement.Name)
 		return nil
 	}
 
@@ -222,33 +263,39 @@ func (c *schedulingController) syncPlacement(ctx context.Context, syncCtx factor
 		return nil
 	}
 
-	// get all valid clustersetbindings in the placement namespace
-	bindings, err := c.getValidManagedClusterSetBindings(placement.Namespace)
+	// get the clusters the placement can select from
+	_, resolveSpan := tracing.Tracer().Start(ctx, "ResolveClusterSets")
+	eligible, err := c.getEligibleClusters(placement)
+	if err == nil {
+		resolveSpan.SetAttributes(
+			tracing.BindingsKey.Int(len(eligible.Bindings)),
+			tracing.ClusterSetsKey.StringSlice(eligible.ClusterSets),
+			tracing.ClustersKey.Int(len(eligible.Clusters)),
+		)
+	}
+	tracing.RecordError(resolveSpan, err)
+	resolveSpan.End()
 	if err != nil {
 		return err
 	}
 
-	// get eligible clustersets for the placement
-	clusterSetNames := c.getEligibleClusterSets(placement, bindings)
-
-	// get available clusters for the placement
-	clusters, err := c.getAvailableClusters(clusterSetNames)
+	// schedule placement with scheduler and reserve the decisions
+	scheduleResult, status, err := c.schedule(ctx, placement, eligible.Clusters)
 	if err != nil {
 		return err
 	}
-
-	// schedule placement with scheduler
-	scheduleResult, status := c.scheduler.Schedule(ctx, placement, clusters)
 	misconfiguredCondition := newMisconfiguredCondition(status)
 	satisfiedCondition := newSatisfiedCondition(
 		placement.Spec.ClusterSets,
-		clusterSetNames,
-		len(bindings),
-		len(clusters),
+		eligible.ClusterSets,
+		len(eligible.Bindings),
+		len(eligible.Clusters),
 		len(scheduleResult.Decisions()),
 		scheduleResult.NumOfUnscheduled(),
 		status,
 	)
+	metrics.RecordScheduleStatus(status)
+	metrics.SetPlacementSatisfied(placement.Namespace+"/"+placement.Name, satisfiedCondition.Status == metav1.ConditionTrue)
 
 	// requeue placement if requeueAfter is defined in scheduleResult
 	if syncCtx != nil && scheduleResult.RequeueAfter() != nil {
@@ -258,18 +305,109 @@ func (c *schedulingController) syncPlacement(ctx context.Context, syncCtx factor
 		syncCtx.Queue().AddAfter(key, *t)
 	}
 
+	// run the Unreserve and PostBind plugins around binding if the scheduler has them
+	binder, hasBindingPlugins := c.scheduler.(BindingPlugins)
 	if err := c.bind(ctx, placement, scheduleResult.Decisions(), scheduleResult.PrioritizerScores(), status); err != nil {
+		if hasBindingPlugins {
+			binder.Unreserve(ctx, placement, scheduleResult.Decisions())
+		}
 		return err
 	}
 
+	if hasBindingPlugins {
+		binder.PostBind(ctx, placement, scheduleResult.Decisions())
+	}
+
 	// update placement status if necessary to signal no bindings
-	if err := c.updateStatus(ctx, placement, int32(len(scheduleResult.Decisions())), misconfiguredCondition, satisfiedCondition); err != nil {
+	if err := c.patchPlacementStatus(ctx, placement, int32(len(scheduleResult.Decisions())), misconfiguredCondition, satisfiedCondition); err != nil {
 		return err
 	}
 
+	// an unsatisfiable spread constraint is reported in the satisfied condition, like the other
+	// unscheduled decisions, and the placement is scheduled again once the clusters change
+	if status.Code() == framework.Warning && status.Plugin() == spreadPolicyName {
+		return nil
+	}
 	return status.AsError()
 }
 
+// schedule schedules the placement and reserves the decisions if the scheduler has the
+// Reserve plugins. Only one placement is scheduled at a time.
+func (c *schedulingController) schedule(
+	ctx context.Context,
+	placement *clusterapiv1beta1.Placement,
+	clusters []*clusterapiv1.ManagedCluster,
+) (ScheduleResult, *framework.Status, error) {
+	ctx, span := tracing.Tracer().Start(ctx, "Schedule", trace.WithAttributes(tracing.ClustersKey.Int(len(clusters))))
+	defer span.End()
+
+	scheduleResult, status := c.scheduler.Schedule(ctx, placement, clusters)
+	if binder, ok := c.scheduler.(BindingPlugins); ok {
+		if reserveStatus := binder.Reserve(ctx, placement, scheduleResult.Decisions()); reserveStatus.IsError() {
+			tracing.RecordStatus(span, reserveStatus)
+			return nil, nil, reserveStatus.AsError()
+		}
+	}
+	span.SetAttributes(
+		tracing.DecisionsKey.Int(len(scheduleResult.Decisions())),
+		tracing.UnscheduledKey.Int(scheduleResult.NumOfUnscheduled()),
+	)
+	tracing.RecordStatus(span, status)
+	return scheduleResult, status, nil
+}
+
+// EligibleClusters are the clusters a placement can select from, with the clustersetbindings
+// and clustersets they are resolved from.
+type EligibleClusters struct {
+	// Bindings are the valid clustersetbindings in the placement namespace.
+	Bindings []*clusterapiv1beta2.ManagedClusterSetBinding
+	// ClusterSets are the names of the clustersets eligible for the placement.
+	ClusterSets []string
+	// Clusters are the clusters in the eligible clustersets.
+	Clusters []*clusterapiv1.ManagedCluster
+}
+
+// GetEligibleClusters resolves the clusters the placement can select from with the listers, in
+// the same way the scheduling controller does before scheduling the placement.
+func GetEligibleClusters(
+	clusterLister clusterlisterv1.ManagedClusterLister,
+	clusterSetLister clusterlisterv1beta2.ManagedClusterSetLister,
+	clusterSetBindingLister clusterlisterv1beta2.ManagedClusterSetBindingLister,
+	placement *clusterapiv1beta1.Placement,
+) (*EligibleClusters, error) {
+	c := &schedulingController{
+		clusterLister:           clusterLister,
+		clusterSetLister:        clusterSetLister,
+		clusterSetBindingLister: clusterSetBindingLister,
+	}
+	return c.getEligibleClusters(placement)
+}
+
+// getEligibleClusters returns the clusters from the clustersets which are bound to the
+// placement namespace and specified in the placement.
+func (c *schedulingController) getEligibleClusters(placement *clusterapiv1beta1.Placement) (*EligibleClusters, error) {
+	// get all valid clustersetbindings in the placement namespace
+	bindings, err := c.getValidManagedClusterSetBindings(placement.Namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	// get eligible clustersets for the placement
+	clusterSetNames := c.getEligibleClusterSets(placement, bindings)
+
+	// get available clusters for the placement
+	clusters, err := c.getAvailableClusters(clusterSetNames)
+	if err != nil {
+		return nil, err
+	}
+
+	return &EligibleClusters{
+		Bindings:    bindings,
+		ClusterSets: clusterSetNames,
+		Clusters:    clusters,
+	}, nil
+}
+
 // getManagedClusterSetBindings returns all bindings found in the placement namespace.
 func (c *schedulingController) getValidManagedClusterSetBindings(placementNamespace string) ([]*clusterapiv1beta2.ManagedClusterSetBinding, error) {
 	// get all clusterset bindings under the placement namespace
@@ -354,29 +492,6 @@ func (c *schedulingController) getAvailableClusters(clusterSetNames []string) ([
 	return result, nil
 }
 
-// updateStatus updates the status of the placement according to intermediate scheduling data.
-func (c *schedulingController) updateStatus(
-	ctx context.Context,
-	placement *clusterapiv1beta1.Placement,
-	numberOfSelectedClusters int32,
-	conditions ...metav1.Condition,
-) error {
-	newPlacement := placement.DeepCopy()
-	newPlacement.Status.NumberOfSelectedClusters = numberOfSelectedClusters
-
-	for _, c := range conditions {
-		meta.SetStatusCondition(&newPlacement.Status.Conditions, c)
-	}
-	if reflect.DeepEqual(newPlacement.Status, placement.Status) {
-		return nil
-	}
-
-	_, err := c.clusterClient.ClusterV1beta1().
-		Placements(newPlacement.Namespace).
-		UpdateStatus(ctx, newPlacement, metav1.UpdateOptions{})
-	return err
-}
-
 // newSatisfiedCondition returns a new condition with type PlacementConditionSatisfied
 func newSatisfiedCondition(
 	clusterSetsInSpec []string,
@@ -419,6 +534,10 @@ func newSatisfiedCondition(
 		condition.Status = metav1.ConditionFalse
 		condition.Reason = "NotAllDecisionsScheduled"
 		condition.Message = fmt.Sprintf("%d cluster decisions unscheduled", numOfUnscheduledDecisions)
+		// explain why decisions are left unscheduled by a DoNotSchedule spread constraint
+		if status.Code() == framework.Warning && status.Plugin() == spreadPolicyName {
+			condition.Message = fmt.Sprintf("%s: %s", condition.Message, status.Message())
+		}
 	}
 	return condition
 }
@@ -449,43 +568,43 @@ func (c *schedulingController) bind(
 	clusterDecisions []clusterapiv1beta1.ClusterDecision,
 	clusterScores PrioritizerScore,
 	status *framework.Status,
-) error {
-	// sort clusterdecisions by cluster name
-	sort.SliceStable(clusterDecisions, func(i, j int) bool {
-		return clusterDecisions[i].ClusterName < clusterDecisions[j].ClusterName
-	})
+) (err error) {
+	ctx, span := tracing.Tracer().Start(ctx, "Bind", trace.WithAttributes(tracing.DecisionsKey.Int(len(clusterDecisions))))
+	defer func() {
+		tracing.RecordError(span, err)
+		span.End()
+	}()
 
-	// split the cluster decisions into slices, the size of each slice cannot exceed
-	// maxNumOfClusterDecisions.
-	decisionSlices := [][]clusterapiv1beta1.ClusterDecision{}
-	remainingDecisions := clusterDecisions
-	for index := 0; len(remainingDecisions) > 0; index++ {
-		var decisionSlice []clusterapiv1beta1.ClusterDecision
-		switch {
-		case len(remainingDecisions) > maxNumOfClusterDecisions:
-			decisionSlice = remainingDecisions[0:maxNumOfClusterDecisions]
-			remainingDecisions = remainingDecisions[maxNumOfClusterDecisions:]
-		default:
-			decisionSlice = remainingDecisions
-			remainingDecisions = nil
-		}
-		decisionSlices = append(decisionSlices, decisionSlice)
+	// query all placementdecisions of the placement
+	requirement, err := labels.NewRequirement(placementLabel, selection.Equals, []string{placement.Name})
+	if err != nil {
+		return err
+	}
+	labelSelector := labels.NewSelector().Add(*requirement)
+	placementDecisions, err := c.placementDecisionLister.PlacementDecisions(placement.Namespace).List(labelSelector)
+	if err != nil {
+		return err
 	}
-	// if decisionSlices is empty, append one empty slice.
-	// so that can create a PlacementDecision with empty decisions in status.
-	if len(decisionSlices) == 0 {
-		decisionSlices = append(decisionSlices, []clusterapiv1beta1.ClusterDecision{})
+	previousClusters := sets.NewString()
+	for _, placementDecision := range placementDecisions {
+		for _, decision := range placementDecision.Status.Decisions {
+			previousClusters.Insert(decision.ClusterName)
+		}
 	}
 
-	// bind cluster decision slices to placementdecisions.
+	// split the cluster decisions into shards, the size of each shard cannot exceed the
+	// decision shard size. The clusters are kept in the placementdecisions they are already in.
+	decisionShards := shardDecisions(placement.Name, clusterDecisions, placementDecisions, c.decisionShardSize())
+
+	// bind cluster decision shards to placementdecisions.
 	errs := []error{}
 
 	placementDecisionNames := sets.NewString()
-	for index, decisionSlice := range decisionSlices {
-		placementDecisionName := fmt.Sprintf("%s-decision-%d", placement.Name, index+1)
-		placementDecisionNames.Insert(placementDecisionName)
+	for _, shard := range decisionShards {
+		decisionName := placementDecisionName(placement.Name, shard.index)
+		placementDecisionNames.Insert(decisionName)
 		err := c.createOrUpdatePlacementDecision(
-			ctx, placement, placementDecisionName, decisionSlice, clusterScores, status)
+			ctx, placement, decisionName, shard.decisions, clusterScores, status)
 		if err != nil {
 			errs = append(errs, err)
 		}
@@ -494,37 +613,59 @@ func (c *schedulingController) bind(
 		return errorhelpers.NewMultiLineAggregate(errs)
 	}
 
-	// query all placementdecisions of the placement
-	requirement, err := labels.NewRequirement(placementLabel, selection.Equals, []string{placement.Name})
-	if err != nil {
-		return err
-	}
-	labelSelector := labels.NewSelector().Add(*requirement)
-	placementDecisions, err := c.placementDecisionLister.PlacementDecisions(placement.Namespace).List(labelSelector)
-	if err != nil {
-		return err
-	}
-
 	// delete redundant placementdecisions
 	errs = []error{}
 	for _, placementDecision := range placementDecisions {
 		if placementDecisionNames.Has(placementDecision.Name) {
 			continue
 		}
-		err := c.clusterClient.ClusterV1beta1().PlacementDecisions(
-			placementDecision.Namespace).Delete(ctx, placementDecision.Name, metav1.DeleteOptions{})
+		err := c.deletePlacementDecision(ctx, placementDecision)
 		if errors.IsNotFound(err) {
 			continue
 		}
 		if err != nil {
 			errs = append(errs, err)
+		} else {
+			metrics.RecordPlacementDecisionOperation(metrics.OperationDelete)
 		}
 		c.recorder.Eventf(
 			placement, placementDecision, corev1.EventTypeNormal,
 			"DecisionDelete", "DecisionDeleted",
 			"Decision %s is deleted with placement %s in namespace %s", placementDecision.Name, placement.Name, placement.Namespace)
 	}
-	return errorhelpers.NewMultiLineAggregate(errs)
+	if len(errs) != 0 {
+		return errorhelpers.NewMultiLineAggregate(errs)
+	}
+
+	currentClusters := sets.NewString()
+	for _, decision := range clusterDecisions {
+		currentClusters.Insert(decision.ClusterName)
+	}
+	metrics.RecordDecisionChanges(placement.Namespace, placement.Name,
+		currentClusters.Difference(previousClusters).Len(), previousClusters.Difference(currentClusters).Len())
+	return nil
+}
+
+// decisionShardSize returns the max number of decisions in a placementdecision.
+func (c *schedulingController) decisionShardSize() int {
+	if c.decisionsPerPlacementDecision <= 0 || c.decisionsPerPlacementDecision > maxNumOfClusterDecisions {
+		return maxNumOfClusterDecisions
+	}
+	return c.decisionsPerPlacementDecision
+}
+
+// deletePlacementDecision deletes the redundant placementdecision.
+func (c *schedulingController) deletePlacementDecision(ctx context.Context, placementDecision *clusterapiv1beta1.PlacementDecision) error {
+	ctx, span := tracing.Tracer().Start(ctx, "WritePlacementDecision", trace.WithAttributes(
+		tracing.PlacementDecisionKey.String(placementDecision.Name),
+		tracing.OperationKey.String(metrics.OperationDelete),
+	))
+	defer span.End()
+
+	err := c.clusterClient.ClusterV1beta1().PlacementDecisions(
+		placementDecision.Namespace).Delete(ctx, placementDecision.Name, metav1.DeleteOptions{})
+	tracing.RecordError(span, err)
+	return err
 }
 
 // createOrUpdatePlacementDecision creates a new PlacementDecision if it does not exist and
@@ -536,7 +677,16 @@ func (c *schedulingController) createOrUpdatePlacementDecision(
 	clusterDecisions []clusterapiv1beta1.ClusterDecision,
 	clusterScores PrioritizerScore,
 	status *framework.Status,
-) error {
+) (err error) {
+	ctx, span := tracing.Tracer().Start(ctx, "WritePlacementDecision", trace.WithAttributes(
+		tracing.PlacementDecisionKey.String(placementDecisionName),
+		tracing.DecisionsKey.Int(len(clusterDecisions)),
+	))
+	defer func() {
+		tracing.RecordError(span, err)
+		span.End()
+	}()
+
 	if len(clusterDecisions) > maxNumOfClusterDecisions {
 		return fmt.Errorf("the number of clusterdecisions %q exceeds the max limitation %q", len(clusterDecisions), maxNumOfClusterDecisions)
 	}
@@ -562,6 +712,8 @@ func (c *schedulingController) createOrUpdatePlacementDecision(
 		if err != nil {
 			return err
 		}
+		metrics.RecordPlacementDecisionOperation(metrics.OperationCreate)
+		span.AddEvent("PlacementDecisionCreated")
 		c.recorder.Eventf(
 			placement, placementDecision, corev1.EventTypeNormal,
 			"DecisionCreate", "DecisionCreated",
@@ -571,18 +723,15 @@ func (c *schedulingController) createOrUpdatePlacementDecision(
 	}
 
 	// update the status of the placementdecision if decisions change
-	if apiequality.Semantic.DeepEqual(placementDecision.Status.Decisions, clusterDecisions) {
-		return nil
-	}
-
-	newPlacementDecision := placementDecision.DeepCopy()
-	newPlacementDecision.Status.Decisions = clusterDecisions
-	newPlacementDecision, err = c.clusterClient.ClusterV1beta1().PlacementDecisions(newPlacementDecision.Namespace).
-		UpdateStatus(ctx, newPlacementDecision, metav1.UpdateOptions{})
-
+	patched, err := c.patchPlacementDecisionStatus(ctx, placementDecision, clusterDecisions)
 	if err != nil {
 		return err
 	}
+	if !patched {
+		return nil
+	}
+	span.SetAttributes(tracing.OperationKey.String(metrics.OperationUpdate))
+	metrics.RecordPlacementDecisionOperation(metrics.OperationUpdate)
 
 	// update the event with warning
 	if status.Code() == framework.Warning {
@@ -2,35 +2,48 @@ package scheduling
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"sort"
 	"strings"
 	"testing"
+	"time"
 
+	"github.com/openshift/library-go/pkg/controller/factory"
+	"go.opentelemetry.io/otel/attribute"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/util/rand"
 	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/wait"
 	clienttesting "k8s.io/client-go/testing"
 	kevents "k8s.io/client-go/tools/events"
+	"k8s.io/client-go/util/workqueue"
+	"k8s.io/component-base/metrics/legacyregistry"
+	"k8s.io/component-base/metrics/testutil"
 	clusterfake "open-cluster-management.io/api/client/cluster/clientset/versioned/fake"
 	clusterapiv1 "open-cluster-management.io/api/cluster/v1"
 	clusterapiv1beta1 "open-cluster-management.io/api/cluster/v1beta1"
 	clusterapiv1beta2 "open-cluster-management.io/api/cluster/v1beta2"
 
 	"open-cluster-management.io/placement/pkg/controllers/framework"
+	"open-cluster-management.io/placement/pkg/controllers/metrics"
+	"open-cluster-management.io/placement/pkg/controllers/tracing"
 	testinghelpers "open-cluster-management.io/placement/pkg/helpers/testing"
 )
 
 type testScheduler struct {
 	result ScheduleResult
+	status *framework.Status
 }
 
 func (s *testScheduler) Schedule(ctx context.Context,
 	placement *clusterapiv1beta1.Placement,
 	clusters []*clusterapiv1.ManagedCluster,
 ) (ScheduleResult, *framework.Status) {
-	return s.result, nil
+	return s.result, s.status
 }
 
 func TestSchedulingController_sync(t *testing.T) {
@@ -42,6 +55,7 @@ func TestSchedulingController_sync(t *testing.T) {
 		placement       *clusterapiv1beta1.Placement
 		initObjs        []runtime.Object
 		scheduleResult  *scheduleResult
+		status          *framework.Status
 		validateActions func(t *testing.T, actions []clienttesting.Action)
 	}{
 		{
@@ -62,9 +76,9 @@ func TestSchedulingController_sync(t *testing.T) {
 			},
 			validateActions: func(t *testing.T, actions []clienttesting.Action) {
 				// check if PlacementDecision has been updated
-				testinghelpers.AssertActions(t, actions, "create", "update", "update")
+				testinghelpers.AssertActions(t, actions, "create", "patch", "patch")
 				// check if Placement has been updated
-				actual := actions[2].(clienttesting.UpdateActionImpl).Object
+				actual := patchedObject(t, actions[2])
 				placement, ok := actual.(*clusterapiv1beta1.Placement)
 				if !ok {
 					t.Errorf("expected Placement was updated")
@@ -104,9 +118,9 @@ func TestSchedulingController_sync(t *testing.T) {
 			},
 			validateActions: func(t *testing.T, actions []clienttesting.Action) {
 				// check if PlacementDecision has been updated
-				testinghelpers.AssertActions(t, actions, "create", "update", "update")
+				testinghelpers.AssertActions(t, actions, "create", "patch", "patch")
 				// check if Placement has been updated
-				actual := actions[2].(clienttesting.UpdateActionImpl).Object
+				actual := patchedObject(t, actions[2])
 				placement, ok := actual.(*clusterapiv1beta1.Placement)
 				if !ok {
 					t.Errorf("expected Placement was updated")
@@ -133,7 +147,7 @@ func TestSchedulingController_sync(t *testing.T) {
 			},
 			validateActions: func(t *testing.T, actions []clienttesting.Action) {
 				// check if PlacementDecision has been updated
-				testinghelpers.AssertActions(t, actions, "create", "update")
+				testinghelpers.AssertActions(t, actions, "create", "patch")
 				// check if emtpy PlacementDecision has been created
 				actual := actions[0].(clienttesting.CreateActionImpl).Object
 				placementDecision, ok := actual.(*clusterapiv1beta1.PlacementDecision)
@@ -145,7 +159,7 @@ func TestSchedulingController_sync(t *testing.T) {
 					t.Errorf("expecte %d cluster selected, but got %d", 0, len(placementDecision.Status.Decisions))
 				}
 				// check if Placement has been updated
-				actual = actions[1].(clienttesting.UpdateActionImpl).Object
+				actual = patchedObject(t, actions[1])
 				placement, ok := actual.(*clusterapiv1beta1.Placement)
 				if !ok {
 					t.Errorf("expected Placement was updated")
@@ -176,7 +190,7 @@ func TestSchedulingController_sync(t *testing.T) {
 			},
 			validateActions: func(t *testing.T, actions []clienttesting.Action) {
 				// check if PlacementDecision has been updated
-				testinghelpers.AssertActions(t, actions, "create", "update")
+				testinghelpers.AssertActions(t, actions, "create", "patch")
 				// check if emtpy PlacementDecision has been created
 				actual := actions[0].(clienttesting.CreateActionImpl).Object
 				placementDecision, ok := actual.(*clusterapiv1beta1.PlacementDecision)
@@ -188,7 +202,7 @@ func TestSchedulingController_sync(t *testing.T) {
 					t.Errorf("expecte %d cluster selected, but got %d", 0, len(placementDecision.Status.Decisions))
 				}
 				// check if Placement has been updated
-				actual = actions[1].(clienttesting.UpdateActionImpl).Object
+				actual = patchedObject(t, actions[1])
 				placement, ok := actual.(*clusterapiv1beta1.Placement)
 				if !ok {
 					t.Errorf("expected Placement was updated")
@@ -222,7 +236,7 @@ func TestSchedulingController_sync(t *testing.T) {
 			},
 			validateActions: func(t *testing.T, actions []clienttesting.Action) {
 				// check if PlacementDecision has been updated
-				testinghelpers.AssertActions(t, actions, "create", "update")
+				testinghelpers.AssertActions(t, actions, "create", "patch")
 				// check if emtpy PlacementDecision has been created
 				actual := actions[0].(clienttesting.CreateActionImpl).Object
 				placementDecision, ok := actual.(*clusterapiv1beta1.PlacementDecision)
@@ -234,7 +248,7 @@ func TestSchedulingController_sync(t *testing.T) {
 					t.Errorf("expecte %d cluster selected, but got %d", 0, len(placementDecision.Status.Decisions))
 				}
 				// check if Placement has been updated
-				actual = actions[1].(clienttesting.UpdateActionImpl).Object
+				actual = patchedObject(t, actions[1])
 				placement, ok := actual.(*clusterapiv1beta1.Placement)
 				if !ok {
 					t.Errorf("expected Placement was updated")
@@ -278,6 +292,37 @@ func TestSchedulingController_sync(t *testing.T) {
 			},
 			validateActions: testinghelpers.AssertNoActions,
 		},
+		{
+			name:      "spread constraint cannot be satisfied",
+			placement: testinghelpers.NewPlacement(placementNamespace, placementName).WithNOC(3).Build(),
+			initObjs: []runtime.Object{
+				testinghelpers.NewClusterSet("clusterset1").Build(),
+				testinghelpers.NewClusterSetBinding(placementNamespace, "clusterset1"),
+				testinghelpers.NewManagedCluster("cluster1").WithLabel(clusterSetLabel, "clusterset1").Build(),
+				testinghelpers.NewManagedCluster("cluster2").WithLabel(clusterSetLabel, "clusterset1").Build(),
+			},
+			scheduleResult: &scheduleResult{
+				feasibleClusters: []*clusterapiv1.ManagedCluster{
+					testinghelpers.NewManagedCluster("cluster1").Build(),
+					testinghelpers.NewManagedCluster("cluster2").Build(),
+				},
+				scheduledDecisions:   []clusterapiv1beta1.ClusterDecision{{ClusterName: "cluster1"}},
+				unscheduledDecisions: 2,
+			},
+			status: framework.NewStatus(spreadPolicyName, framework.Warning, "spread constraint cannot be satisfied"),
+			validateActions: func(t *testing.T, actions []clienttesting.Action) {
+				testinghelpers.AssertActions(t, actions, "create", "patch", "patch")
+				placement := patchedObject(t, actions[2]).(*clusterapiv1beta1.Placement)
+				if !testinghelpers.HasCondition(placement.Status.Conditions, clusterapiv1beta1.PlacementConditionSatisfied,
+					"NotAllDecisionsScheduled", metav1.ConditionFalse) {
+					t.Errorf("expected condition not satisfied, but got %v", placement.Status.Conditions)
+				}
+				condition := meta.FindStatusCondition(placement.Status.Conditions, clusterapiv1beta1.PlacementConditionSatisfied)
+				if condition == nil || !strings.Contains(condition.Message, "spread constraint cannot be satisfied") {
+					t.Errorf("expected the spread constraint in the condition, but got %v", condition)
+				}
+			},
+		},
 		{
 			name: "placement schedule controller is disabled",
 			placement: testinghelpers.NewPlacementWithAnnotations(placementNamespace, placementName,
@@ -301,7 +346,7 @@ func TestSchedulingController_sync(t *testing.T) {
 			c.initObjs = append(c.initObjs, c.placement)
 			clusterClient := clusterfake.NewSimpleClientset(c.initObjs...)
 			clusterInformerFactory := newClusterInformerFactory(clusterClient, c.initObjs...)
-			s := &testScheduler{result: c.scheduleResult}
+			s := &testScheduler{result: c.scheduleResult, status: c.status}
 
 			ctrl := schedulingController{
 				clusterClient:           clusterClient,
@@ -325,6 +370,176 @@ func TestSchedulingController_sync(t *testing.T) {
 	}
 }
 
+func TestSyncPlacementTwice(t *testing.T) {
+	placementNamespace := "ns1"
+	placementName := "placement1"
+	initObjs := []runtime.Object{
+		testinghelpers.NewClusterSet("clusterset1").Build(),
+		testinghelpers.NewClusterSetBinding(placementNamespace, "clusterset1"),
+		testinghelpers.NewManagedCluster("cluster1").WithLabel(clusterSetLabel, "clusterset1").Build(),
+		testinghelpers.NewManagedCluster("cluster2").WithLabel(clusterSetLabel, "clusterset1").Build(),
+		testinghelpers.NewManagedCluster("cluster3").WithLabel(clusterSetLabel, "clusterset1").Build(),
+		testinghelpers.NewPlacement(placementNamespace, placementName).WithNOC(2).Build(),
+	}
+
+	sync := func(objs []runtime.Object) *clusterfake.Clientset {
+		clusterClient := clusterfake.NewSimpleClientset(objs...)
+		clusterInformerFactory := newClusterInformerFactory(clusterClient, objs...)
+		ctrl := schedulingController{
+			clusterClient:           clusterClient,
+			clusterLister:           clusterInformerFactory.Cluster().V1().ManagedClusters().Lister(),
+			clusterSetLister:        clusterInformerFactory.Cluster().V1beta2().ManagedClusterSets().Lister(),
+			clusterSetBindingLister: clusterInformerFactory.Cluster().V1beta2().ManagedClusterSetBindings().Lister(),
+			placementLister:         clusterInformerFactory.Cluster().V1beta1().Placements().Lister(),
+			placementDecisionLister: clusterInformerFactory.Cluster().V1beta1().PlacementDecisions().Lister(),
+			scheduler:               NewPluginScheduler(testinghelpers.NewFakePluginHandle(t, clusterClient, objs...)),
+			recorder:                kevents.NewFakeRecorder(100),
+		}
+		if err := ctrl.sync(context.TODO(), testinghelpers.NewFakeSyncContext(t, placementNamespace+"/"+placementName)); err != nil {
+			t.Fatalf("unexpected err: %v", err)
+		}
+		return clusterClient
+	}
+
+	// the first sync selects the clusters as new ones
+	clusterClient := sync(initObjs)
+	objs := initObjs[:len(initObjs)-1]
+	placement, err := clusterClient.ClusterV1beta1().Placements(placementNamespace).Get(context.TODO(), placementName, metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+	objs = append(objs, placement)
+	placementDecision, err := clusterClient.ClusterV1beta1().PlacementDecisions(placementNamespace).Get(
+		context.TODO(), placementDecisionName(placementName, 1), metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+	assertClustersSelected(t, placementDecision.Status.Decisions, "cluster1", "cluster2")
+	objs = append(objs, placementDecision)
+
+	// the second sync keeps the clusters with other scores, which does not rewrite the decisions
+	clusterClient = sync(objs)
+	testinghelpers.AssertNoActions(t, clusterClient.Actions())
+}
+
+func TestSyncPlacementTracing(t *testing.T) {
+	recorder := testinghelpers.NewSpanRecorder(t)
+
+	placement := testinghelpers.NewPlacement("ns1", "placement1").Build()
+	initObjs := []runtime.Object{
+		placement,
+		testinghelpers.NewClusterSet("clusterset1").Build(),
+		testinghelpers.NewClusterSetBinding("ns1", "clusterset1"),
+		testinghelpers.NewManagedCluster("cluster1").WithLabel(clusterSetLabel, "clusterset1").Build(),
+	}
+	clusterClient := clusterfake.NewSimpleClientset(initObjs...)
+	clusterInformerFactory := newClusterInformerFactory(clusterClient, initObjs...)
+	ctrl := schedulingController{
+		clusterClient:           clusterClient,
+		clusterLister:           clusterInformerFactory.Cluster().V1().ManagedClusters().Lister(),
+		clusterSetLister:        clusterInformerFactory.Cluster().V1beta2().ManagedClusterSets().Lister(),
+		clusterSetBindingLister: clusterInformerFactory.Cluster().V1beta2().ManagedClusterSetBindings().Lister(),
+		placementLister:         clusterInformerFactory.Cluster().V1beta1().Placements().Lister(),
+		placementDecisionLister: clusterInformerFactory.Cluster().V1beta1().PlacementDecisions().Lister(),
+		scheduler: &testScheduler{result: &scheduleResult{
+			scheduledDecisions: []clusterapiv1beta1.ClusterDecision{{ClusterName: "cluster1"}},
+		}},
+		recorder: kevents.NewFakeRecorder(100),
+	}
+
+	if err := ctrl.sync(context.TODO(), testinghelpers.NewFakeSyncContext(t, "ns1/placement1")); err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+
+	spans := map[string]sdktrace.ReadOnlySpan{}
+	for _, span := range recorder.Spans() {
+		spans[span.Name()] = span
+	}
+	// each span is the child of the span on its right
+	parents := map[string]string{
+		"ResolveClusterSets":     "SyncPlacement",
+		"Schedule":               "SyncPlacement",
+		"Bind":                   "SyncPlacement",
+		"WritePlacementDecision": "Bind",
+	}
+	for name, parentName := range parents {
+		span, ok := spans[name]
+		if !ok {
+			t.Fatalf("expected span %s, but got %v", name, recorder.Spans())
+		}
+		if span.Parent().SpanID() != spans[parentName].SpanContext().SpanID() {
+			t.Errorf("expected span %s is the child of %s", name, parentName)
+		}
+	}
+
+	expectedAttributes := map[string]attribute.KeyValue{
+		"SyncPlacement":          tracing.PlacementNameKey.String("placement1"),
+		"ResolveClusterSets":     tracing.ClustersKey.Int(1),
+		"Schedule":               tracing.DecisionsKey.Int(1),
+		"WritePlacementDecision": tracing.OperationKey.String("update"),
+	}
+	for name, expected := range expectedAttributes {
+		found := false
+		for _, attr := range spans[name].Attributes() {
+			if attr == expected {
+				found = true
+			}
+		}
+		if !found {
+			t.Errorf("expected attribute %v of span %s, but got %v", expected, name, spans[name].Attributes())
+		}
+	}
+}
+
+// blockingController runs until the context is done.
+type blockingController struct {
+	factory.Controller
+}
+
+func (c *blockingController) Run(ctx context.Context, workers int) {
+	<-ctx.Done()
+}
+
+func TestQueueDepthSampler(t *testing.T) {
+	metrics.Register()
+	defer func(period time.Duration) { queueDepthSamplingPeriod = period }(queueDepthSamplingPeriod)
+	queueDepthSamplingPeriod = time.Millisecond * 10
+
+	queue := workqueue.NewRateLimitingQueue(workqueue.DefaultControllerRateLimiter())
+	queue.Add("ns1/placement1")
+	queue.Add("ns1/placement2")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	sampler := &queueDepthSampler{Controller: &blockingController{}, queue: queue}
+	go sampler.Run(ctx, 1)
+
+	expected := func(depth int) string {
+		return fmt.Sprintf(`
+# HELP placement_queue_depth [ALPHA] Number of placements waiting in the queue of the scheduling controller.
+# TYPE placement_queue_depth gauge
+placement_queue_depth %d
+`, depth)
+	}
+	assertQueueDepth := func(depth int) {
+		err := wait.PollImmediate(queueDepthSamplingPeriod, time.Second*5, func() (bool, error) {
+			return testutil.GatherAndCompare(legacyregistry.DefaultGatherer,
+				strings.NewReader(expected(depth)), "placement_queue_depth") == nil, nil
+		})
+		if err != nil {
+			t.Errorf("expected queue depth %d, but got err: %v", depth, err)
+		}
+	}
+
+	assertQueueDepth(2)
+	// the depth is recorded without syncing any placement
+	for i := 0; i < 2; i++ {
+		item, _ := queue.Get()
+		queue.Done(item)
+	}
+	assertQueueDepth(0)
+}
+
 func TestGetValidManagedClusterSetBindings(t *testing.T) {
 	placementNamespace := "ns1"
 	cases := []struct {
@@ -728,17 +943,18 @@ func TestBind(t *testing.T) {
 	placementName := "placement1"
 
 	cases := []struct {
-		name             string
-		initObjs         []runtime.Object
-		clusterDecisions []clusterapiv1beta1.ClusterDecision
-		validateActions  func(t *testing.T, actions []clienttesting.Action)
+		name                          string
+		initObjs                      []runtime.Object
+		clusterDecisions              []clusterapiv1beta1.ClusterDecision
+		decisionsPerPlacementDecision int
+		validateActions               func(t *testing.T, actions []clienttesting.Action)
 	}{
 		{
 			name:             "create single placementdecision",
 			clusterDecisions: newClusterDecisions(10),
 			validateActions: func(t *testing.T, actions []clienttesting.Action) {
-				testinghelpers.AssertActions(t, actions, "create", "update")
-				actual := actions[1].(clienttesting.UpdateActionImpl).Object
+				testinghelpers.AssertActions(t, actions, "create", "patch")
+				actual := patchedObject(t, actions[1])
 				placementDecision, ok := actual.(*clusterapiv1beta1.PlacementDecision)
 				if !ok {
 					t.Errorf("expected PlacementDecision was updated")
@@ -750,16 +966,16 @@ func TestBind(t *testing.T) {
 			name:             "create multiple placementdecisions",
 			clusterDecisions: newClusterDecisions(101),
 			validateActions: func(t *testing.T, actions []clienttesting.Action) {
-				testinghelpers.AssertActions(t, actions, "create", "update", "create", "update")
+				testinghelpers.AssertActions(t, actions, "create", "patch", "create", "patch")
 				selectedClusters := newSelectedClusters(101)
-				actual := actions[1].(clienttesting.UpdateActionImpl).Object
+				actual := patchedObject(t, actions[1])
 				placementDecision, ok := actual.(*clusterapiv1beta1.PlacementDecision)
 				if !ok {
 					t.Errorf("expected PlacementDecision was updated")
 				}
 				assertClustersSelected(t, placementDecision.Status.Decisions, selectedClusters[0:100]...)
 
-				actual = actions[3].(clienttesting.UpdateActionImpl).Object
+				actual = patchedObject(t, actions[3])
 				placementDecision, ok = actual.(*clusterapiv1beta1.PlacementDecision)
 				if !ok {
 					t.Errorf("expected PlacementDecision was updated")
@@ -804,9 +1020,9 @@ func TestBind(t *testing.T) {
 					WithDecisions(newSelectedClusters(128)[:100]...).Build(),
 			},
 			validateActions: func(t *testing.T, actions []clienttesting.Action) {
-				testinghelpers.AssertActions(t, actions, "create", "update")
+				testinghelpers.AssertActions(t, actions, "create", "patch")
 				selectedClusters := newSelectedClusters(128)
-				actual := actions[1].(clienttesting.UpdateActionImpl).Object
+				actual := patchedObject(t, actions[1])
 				placementDecision, ok := actual.(*clusterapiv1beta1.PlacementDecision)
 				if !ok {
 					t.Errorf("expected PlacementDecision was updated")
@@ -826,8 +1042,8 @@ func TestBind(t *testing.T) {
 					WithDecisions(newSelectedClusters(128)[100:]...).Build(),
 			},
 			validateActions: func(t *testing.T, actions []clienttesting.Action) {
-				testinghelpers.AssertActions(t, actions, "update", "delete")
-				actual := actions[0].(clienttesting.UpdateActionImpl).Object
+				testinghelpers.AssertActions(t, actions, "patch", "delete")
+				actual := patchedObject(t, actions[0])
 				placementDecision, ok := actual.(*clusterapiv1beta1.PlacementDecision)
 				if !ok {
 					t.Errorf("expected PlacementDecision was updated")
@@ -847,8 +1063,8 @@ func TestBind(t *testing.T) {
 					WithDecisions(newSelectedClusters(128)[100:]...).Build(),
 			},
 			validateActions: func(t *testing.T, actions []clienttesting.Action) {
-				testinghelpers.AssertActions(t, actions, "update", "delete")
-				actual := actions[0].(clienttesting.UpdateActionImpl).Object
+				testinghelpers.AssertActions(t, actions, "patch", "delete")
+				actual := patchedObject(t, actions[0])
 				placementDecision, ok := actual.(*clusterapiv1beta1.PlacementDecision)
 				if !ok {
 					t.Errorf("expected PlacementDecision was updated")
@@ -858,6 +1074,84 @@ func TestBind(t *testing.T) {
 				}
 			},
 		},
+		{
+			name:             "keep clusters in their placementdecisions when a cluster is added",
+			clusterDecisions: append(newClusterDecisions(128), clusterapiv1beta1.ClusterDecision{ClusterName: "aaa"}),
+			initObjs: []runtime.Object{
+				testinghelpers.NewPlacementDecision(placementNamespace, placementDecisionName(placementName, 1)).
+					WithLabel(placementLabel, placementName).
+					WithDecisions(newSelectedClusters(128)[:100]...).Build(),
+				testinghelpers.NewPlacementDecision(placementNamespace, placementDecisionName(placementName, 2)).
+					WithLabel(placementLabel, placementName).
+					WithDecisions(newSelectedClusters(128)[100:]...).Build(),
+			},
+			validateActions: func(t *testing.T, actions []clienttesting.Action) {
+				testinghelpers.AssertActions(t, actions, "patch")
+				placementDecision := patchedObject(t, actions[0]).(*clusterapiv1beta1.PlacementDecision)
+				if placementDecision.Name != placementDecisionName(placementName, 2) {
+					t.Errorf("expected placementdecision %s updated, but got %s", placementDecisionName(placementName, 2), placementDecision.Name)
+				}
+				assertClustersSelected(t, placementDecision.Status.Decisions, append(newSelectedClusters(128)[100:], "aaa")...)
+			},
+		},
+		{
+			name: "fill the gap of a removed cluster",
+			clusterDecisions: append(newClusterDecisions(128)[1:],
+				clusterapiv1beta1.ClusterDecision{ClusterName: "zzz"}),
+			initObjs: []runtime.Object{
+				testinghelpers.NewPlacementDecision(placementNamespace, placementDecisionName(placementName, 1)).
+					WithLabel(placementLabel, placementName).
+					WithDecisions(newSelectedClusters(128)[:100]...).Build(),
+				testinghelpers.NewPlacementDecision(placementNamespace, placementDecisionName(placementName, 2)).
+					WithLabel(placementLabel, placementName).
+					WithDecisions(newSelectedClusters(128)[100:]...).Build(),
+			},
+			validateActions: func(t *testing.T, actions []clienttesting.Action) {
+				testinghelpers.AssertActions(t, actions, "patch")
+				placementDecision := patchedObject(t, actions[0]).(*clusterapiv1beta1.PlacementDecision)
+				if placementDecision.Name != placementDecisionName(placementName, 1) {
+					t.Errorf("expected placementdecision %s updated, but got %s", placementDecisionName(placementName, 1), placementDecision.Name)
+				}
+				if len(placementDecision.Status.Decisions) != 100 {
+					t.Errorf("expected 100 decisions, but got %d", len(placementDecision.Status.Decisions))
+				}
+				assertClustersSelected(t, placementDecision.Status.Decisions, append(newSelectedClusters(128)[1:100], "zzz")...)
+			},
+		},
+		{
+			name:                          "create placementdecisions with the configured size",
+			clusterDecisions:              newClusterDecisions(25),
+			decisionsPerPlacementDecision: 10,
+			validateActions: func(t *testing.T, actions []clienttesting.Action) {
+				testinghelpers.AssertActions(t, actions, "create", "patch", "create", "patch", "create", "patch")
+				selectedClusters := newSelectedClusters(25)
+				for i, expected := range [][]string{selectedClusters[:10], selectedClusters[10:20], selectedClusters[20:]} {
+					placementDecision := patchedObject(t, actions[2*i+1]).(*clusterapiv1beta1.PlacementDecision)
+					if len(placementDecision.Status.Decisions) != len(expected) {
+						t.Errorf("expected %d decisions in %s, but got %d", len(expected), placementDecision.Name, len(placementDecision.Status.Decisions))
+					}
+					assertClustersSelected(t, placementDecision.Status.Decisions, expected...)
+				}
+			},
+		},
+		{
+			name:                          "rebalance fragmented placementdecisions",
+			clusterDecisions:              newClusterDecisions(4),
+			decisionsPerPlacementDecision: 10,
+			initObjs: []runtime.Object{
+				testinghelpers.NewPlacementDecision(placementNamespace, placementDecisionName(placementName, 1)).
+					WithLabel(placementLabel, placementName).
+					WithDecisions("cluster1", "cluster2").Build(),
+				testinghelpers.NewPlacementDecision(placementNamespace, placementDecisionName(placementName, 2)).
+					WithLabel(placementLabel, placementName).
+					WithDecisions("cluster3", "cluster4").Build(),
+			},
+			validateActions: func(t *testing.T, actions []clienttesting.Action) {
+				testinghelpers.AssertActions(t, actions, "patch", "delete")
+				placementDecision := patchedObject(t, actions[0]).(*clusterapiv1beta1.PlacementDecision)
+				assertClustersSelected(t, placementDecision.Status.Decisions, newSelectedClusters(4)...)
+			},
+		},
 	}
 
 	for _, c := range cases {
@@ -889,6 +1183,8 @@ func TestBind(t *testing.T) {
 				placementDecisionLister: clusterInformerFactory.Cluster().V1beta1().PlacementDecisions().Lister(),
 				scheduler:               s,
 				recorder:                kevents.NewFakeRecorder(100),
+
+				decisionsPerPlacementDecision: c.decisionsPerPlacementDecision,
 			}
 
 			err := ctrl.bind(
@@ -939,3 +1235,27 @@ func newSelectedClusters(num int) (clusters []string) {
 
 	return clusters
 }
+
+// patchedObject returns the placement or placementdecision in the merge patch of the action.
+func patchedObject(t *testing.T, action clienttesting.Action) runtime.Object {
+	patchAction, ok := action.(clienttesting.PatchActionImpl)
+	if !ok {
+		t.Fatalf("expected patch action, but got %v", action)
+	}
+
+	var obj runtime.Object
+	switch patchAction.GetResource().Resource {
+	case "placements":
+		obj = &clusterapiv1beta1.Placement{}
+	case "placementdecisions":
+		obj = &clusterapiv1beta1.PlacementDecision{}
+	default:
+		t.Fatalf("unexpected resource %s", patchAction.GetResource().Resource)
+	}
+	if err := json.Unmarshal(patchAction.GetPatch(), obj); err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+	obj.(metav1.Object).SetNamespace(patchAction.GetNamespace())
+	obj.(metav1.Object).SetName(patchAction.GetName())
+	return obj
+}
@@ -0,0 +1,198 @@
+package scheduling
+
+import (
+	"fmt"
+	"math"
+
+	clusterapiv1 "open-cluster-management.io/api/cluster/v1"
+	clusterapiv1beta1 "open-cluster-management.io/api/cluster/v1beta1"
+	"open-cluster-management.io/placement/pkg/controllers/framework"
+)
+
+const (
+	// spreadPolicyName is the name of the selection stage which honors Placement.Spec.SpreadPolicy.
+	spreadPolicyName = "SpreadPolicy"
+
+	// defaultMaxSkew is used when MaxSkew of a spread constraint is not set.
+	defaultMaxSkew int32 = 1
+)
+
+// spreadConstraint tracks the number of selected clusters in each topology of a
+// SpreadConstraintsTerm during cluster selection.
+type spreadConstraint struct {
+	term clusterapiv1beta1.SpreadConstraintsTerm
+	// counts records the number of selected clusters for each topology value. All topology
+	// values found on the feasible clusters are included, even if no cluster is selected yet.
+	counts map[string]int
+}
+
+func newSpreadConstraint(term clusterapiv1beta1.SpreadConstraintsTerm, clusters []*clusterapiv1.ManagedCluster) *spreadConstraint {
+	if term.MaxSkew <= 0 {
+		term.MaxSkew = defaultMaxSkew
+	}
+	if len(term.WhenUnsatisfiable) == 0 {
+		term.WhenUnsatisfiable = clusterapiv1beta1.ScheduleAnyway
+	}
+
+	constraint := &spreadConstraint{
+		term:   term,
+		counts: map[string]int{},
+	}
+	for _, cluster := range clusters {
+		if value, ok := getTopologyValue(cluster, term); ok {
+			constraint.counts[value] = 0
+		}
+	}
+	return constraint
+}
+
+// skews returns the skew of each topology if one more cluster of that topology is selected.
+// Skew is the difference between the number of selected clusters in a topology and the
+// global minimum among all the topologies.
+func (c *spreadConstraint) skews() map[string]int {
+	// find the minimal and the second minimal count, so that the global minimum after
+	// selecting one more cluster can be calculated for each topology in constant time.
+	min, secondMin, numOfMin := math.MaxInt32, math.MaxInt32, 0
+	for _, count := range c.counts {
+		switch {
+		case count < min:
+			secondMin = min
+			min, numOfMin = count, 1
+		case count == min:
+			numOfMin++
+		case count < secondMin:
+			secondMin = count
+		}
+	}
+
+	skews := map[string]int{}
+	for value, count := range c.counts {
+		globalMin := min
+		if count == min && numOfMin == 1 {
+			globalMin = count + 1
+			if secondMin < globalMin {
+				globalMin = secondMin
+			}
+		}
+		skews[value] = count + 1 - globalMin
+	}
+	return skews
+}
+
+// selectClustersWithSpreadPolicy selects at most numOfDecisions clusters from the given cluster
+// slice, which is sorted by score already, and distributes them among the topologies defined in
+// the spread constraints of the placement.
+//
+// The clusters are selected one by one. For each round, the candidates are narrowed down with
+// the spread constraints one after another following their order in the placement spec:
+//  1. the candidates whose topology skew would not exceed MaxSkew are kept;
+//  2. if none of the candidates satisfies a DoNotSchedule constraint, the selection stops;
+//  3. if none of the candidates satisfies a ScheduleAnyway constraint, the candidates with the
+//     minimal skew are kept.
+//
+// The candidate with the highest score is selected at the end of each round. A Warning status is
+// returned if the selection is stopped by a DoNotSchedule constraint.
+func selectClustersWithSpreadPolicy(
+	placement *clusterapiv1beta1.Placement,
+	clusters []*clusterapiv1.ManagedCluster,
+	numOfDecisions int,
+) ([]*clusterapiv1.ManagedCluster, *framework.Status) {
+	constraints := []*spreadConstraint{}
+	for _, term := range placement.Spec.SpreadPolicy.SpreadConstraints {
+		constraints = append(constraints, newSpreadConstraint(term, clusters))
+	}
+
+	selected := []*clusterapiv1.ManagedCluster{}
+	remaining := clusters
+	for len(selected) < numOfDecisions && len(remaining) > 0 {
+		candidates := remaining
+		for _, constraint := range constraints {
+			var unsatisfiable bool
+			candidates, unsatisfiable = constraint.narrow(candidates)
+			if unsatisfiable {
+				return selected, framework.NewStatus(
+					spreadPolicyName,
+					framework.Warning,
+					fmt.Sprintf("spread constraint with topology key %s %q and maxSkew %d cannot be satisfied",
+						constraint.term.TopologyKeyType, constraint.term.TopologyKey, constraint.term.MaxSkew),
+				)
+			}
+		}
+
+		// candidates keep the order of the remaining clusters, so the first one has the highest score
+		cluster := candidates[0]
+		selected = append(selected, cluster)
+		for _, constraint := range constraints {
+			if value, ok := getTopologyValue(cluster, constraint.term); ok {
+				constraint.counts[value]++
+			}
+		}
+
+		rest := []*clusterapiv1.ManagedCluster{}
+		for _, c := range remaining {
+			if c.Name != cluster.Name {
+				rest = append(rest, c)
+			}
+		}
+		remaining = rest
+	}
+
+	return selected, framework.NewStatus(spreadPolicyName, framework.Success, "")
+}
+
+// narrow returns the candidates which satisfy the spread constraint. It returns true if no
+// candidate satisfies a DoNotSchedule constraint.
+func (c *spreadConstraint) narrow(candidates []*clusterapiv1.ManagedCluster) ([]*clusterapiv1.ManagedCluster, bool) {
+	skews := c.skews()
+
+	satisfied := []*clusterapiv1.ManagedCluster{}
+	minSkew := math.MaxInt32
+	for _, cluster := range candidates {
+		value, ok := getTopologyValue(cluster, c.term)
+		if !ok {
+			continue
+		}
+		skew := skews[value]
+		if skew < minSkew {
+			minSkew = skew
+		}
+		if skew <= int(c.term.MaxSkew) {
+			satisfied = append(satisfied, cluster)
+		}
+	}
+
+	switch {
+	case len(satisfied) > 0:
+		return satisfied, false
+	case c.term.WhenUnsatisfiable == clusterapiv1beta1.DoNotSchedule:
+		return nil, true
+	case minSkew == math.MaxInt32:
+		// none of the candidates has the topology key, ignore the constraint
+		return candidates, false
+	}
+
+	leastSkewed := []*clusterapiv1.ManagedCluster{}
+	for _, cluster := range candidates {
+		if value, ok := getTopologyValue(cluster, c.term); ok && skews[value] == minSkew {
+			leastSkewed = append(leastSkewed, cluster)
+		}
+	}
+	return leastSkewed, false
+}
+
+// getTopologyValue returns the value of the topology key on the cluster. The value is read from
+// the labels or the cluster claims according to the TopologyKeyType.
+func getTopologyValue(cluster *clusterapiv1.ManagedCluster, term clusterapiv1beta1.SpreadConstraintsTerm) (string, bool) {
+	switch term.TopologyKeyType {
+	case clusterapiv1beta1.TopologyKeyTypeLabel:
+		value, ok := cluster.Labels[term.TopologyKey]
+		return value, ok
+	case clusterapiv1beta1.TopologyKeyTypeClaim:
+		for _, claim := range cluster.Status.ClusterClaims {
+			if claim.Name == term.TopologyKey {
+				return claim.Value, true
+			}
+		}
+	}
+	return "", false
+}
@@ -0,0 +1,105 @@
+package scheduling
+
+import (
+	"reflect"
+	"testing"
+
+	clusterapiv1 "open-cluster-management.io/api/cluster/v1"
+	clusterapiv1beta1 "open-cluster-management.io/api/cluster/v1beta1"
+	"open-cluster-management.io/placement/pkg/controllers/framework"
+	testinghelpers "open-cluster-management.io/placement/pkg/helpers/testing"
+)
+
+func TestSelectClusters(t *testing.T) {
+	placementNamespace := "ns1"
+	placementName := "placement1"
+
+	// clusters are sorted by score already
+	clusters := []*clusterapiv1.ManagedCluster{
+		testinghelpers.NewManagedCluster("east1").WithLabel("region", "east").WithClaim("zone", "a").Build(),
+		testinghelpers.NewManagedCluster("east2").WithLabel("region", "east").WithClaim("zone", "a").Build(),
+		testinghelpers.NewManagedCluster("east3").WithLabel("region", "east").WithClaim("zone", "b").Build(),
+		testinghelpers.NewManagedCluster("west1").WithLabel("region", "west").WithClaim("zone", "c").Build(),
+		testinghelpers.NewManagedCluster("none1").Build(),
+	}
+
+	cases := []struct {
+		name             string
+		placement        *clusterapiv1beta1.Placement
+		expectedClusters []string
+		expectedCode     framework.Code
+	}{
+		{
+			name:             "no spread constraint",
+			placement:        testinghelpers.NewPlacement(placementNamespace, placementName).WithNOC(3).Build(),
+			expectedClusters: []string{"east1", "east2", "east3"},
+			expectedCode:     framework.Success,
+		},
+		{
+			name:             "negative number of clusters",
+			placement:        testinghelpers.NewPlacement(placementNamespace, placementName).WithNOC(-1).Build(),
+			expectedClusters: []string{},
+			expectedCode:     framework.Success,
+		},
+		{
+			name: "schedule anyway",
+			placement: testinghelpers.NewPlacement(placementNamespace, placementName).WithNOC(4).
+				WithSpreadConstraint("region", clusterapiv1beta1.TopologyKeyTypeLabel, 1, clusterapiv1beta1.ScheduleAnyway).Build(),
+			expectedClusters: []string{"east1", "west1", "east2", "east3"},
+			expectedCode:     framework.Success,
+		},
+		{
+			name: "default maxSkew and whenUnsatisfiable",
+			placement: testinghelpers.NewPlacement(placementNamespace, placementName).WithNOC(5).
+				WithSpreadConstraint("region", clusterapiv1beta1.TopologyKeyTypeLabel, 0, "").Build(),
+			expectedClusters: []string{"east1", "west1", "east2", "east3", "none1"},
+			expectedCode:     framework.Success,
+		},
+		{
+			name: "do not schedule",
+			placement: testinghelpers.NewPlacement(placementNamespace, placementName).WithNOC(4).
+				WithSpreadConstraint("region", clusterapiv1beta1.TopologyKeyTypeLabel, 1, clusterapiv1beta1.DoNotSchedule).Build(),
+			expectedClusters: []string{"east1", "west1", "east2"},
+			expectedCode:     framework.Warning,
+		},
+		{
+			name: "do not schedule with maxSkew 2",
+			placement: testinghelpers.NewPlacement(placementNamespace, placementName).WithNOC(4).
+				WithSpreadConstraint("region", clusterapiv1beta1.TopologyKeyTypeLabel, 2, clusterapiv1beta1.DoNotSchedule).Build(),
+			expectedClusters: []string{"east1", "east2", "west1", "east3"},
+			expectedCode:     framework.Success,
+		},
+		{
+			name: "do not schedule cluster without topology key",
+			placement: testinghelpers.NewPlacement(placementNamespace, placementName).
+				WithSpreadConstraint("zone", clusterapiv1beta1.TopologyKeyTypeClaim, 1, clusterapiv1beta1.DoNotSchedule).Build(),
+			expectedClusters: []string{"east1", "east3", "west1", "east2"},
+			expectedCode:     framework.Warning,
+		},
+		{
+			name: "constraints are considered in order",
+			placement: testinghelpers.NewPlacement(placementNamespace, placementName).WithNOC(3).
+				WithSpreadConstraint("region", clusterapiv1beta1.TopologyKeyTypeLabel, 1, clusterapiv1beta1.ScheduleAnyway).
+				WithSpreadConstraint("zone", clusterapiv1beta1.TopologyKeyTypeClaim, 1, clusterapiv1beta1.ScheduleAnyway).Build(),
+			expectedClusters: []string{"east1", "west1", "east3"},
+			expectedCode:     framework.Success,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			decisions, status := selectClusters(c.placement, clusters)
+			if status.Code() != c.expectedCode {
+				t.Errorf("expected status code %v, but got %v: %s", c.expectedCode, status.Code(), status.Message())
+			}
+
+			actual := []string{}
+			for _, decision := range decisions {
+				actual = append(actual, decision.ClusterName)
+			}
+			if !reflect.DeepEqual(actual, c.expectedClusters) {
+				t.Errorf("expected clusters %v, but got %v", c.expectedClusters, actual)
+			}
+		})
+	}
+}
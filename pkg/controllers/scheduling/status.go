@@ -0,0 +1,144 @@
+package scheduling
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/retry"
+	clusterapiv1beta1 "open-cluster-management.io/api/cluster/v1beta1"
+)
+
+// statusFieldManager is the field manager of the status written by the scheduling controller.
+const statusFieldManager = "placement-controller"
+
+// patchPlacementStatus sets the number of selected clusters and the conditions in the status of
+// the placement with a merge patch. The patch is skipped if the status does not change. On a
+// conflict, the latest placement is read from the hub and the same status is patched again, so
+// the placement does not need to be scheduled again.
+func (c *schedulingController) patchPlacementStatus(
+	ctx context.Context,
+	placement *clusterapiv1beta1.Placement,
+	numberOfSelectedClusters int32,
+	conditions ...metav1.Condition,
+) error {
+	latest := placement
+	return retry.RetryOnConflict(retry.DefaultBackoff, func() error {
+		if latest == nil {
+			var err error
+			latest, err = c.clusterClient.ClusterV1beta1().Placements(placement.Namespace).Get(ctx, placement.Name, metav1.GetOptions{})
+			if err != nil {
+				return err
+			}
+		}
+
+		newStatus := latest.Status.DeepCopy()
+		newStatus.NumberOfSelectedClusters = numberOfSelectedClusters
+		for _, condition := range conditions {
+			meta.SetStatusCondition(&newStatus.Conditions, condition)
+		}
+		if apiequality.Semantic.DeepEqual(*newStatus, latest.Status) {
+			return nil
+		}
+
+		patch, err := statusPatch(latest.ObjectMeta, newStatus)
+		if err != nil {
+			return err
+		}
+		_, err = c.clusterClient.ClusterV1beta1().Placements(placement.Namespace).Patch(
+			ctx, placement.Name, types.MergePatchType, patch, metav1.PatchOptions{FieldManager: statusFieldManager}, "status")
+		if errors.IsConflict(err) {
+			latest = nil
+		}
+		return err
+	})
+}
+
+// patchPlacementDecisionStatus sets the decisions in the status of the placementdecision with a
+// merge patch, and returns whether it is patched. The patch is skipped if the decisions do not
+// change, see equalClusterDecisions. Conflicts are retried with the latest placementdecision.
+func (c *schedulingController) patchPlacementDecisionStatus(
+	ctx context.Context,
+	placementDecision *clusterapiv1beta1.PlacementDecision,
+	clusterDecisions []clusterapiv1beta1.ClusterDecision,
+) (bool, error) {
+	if clusterDecisions == nil {
+		clusterDecisions = []clusterapiv1beta1.ClusterDecision{}
+	}
+
+	patched := false
+	latest := placementDecision
+	err := retry.RetryOnConflict(retry.DefaultBackoff, func() error {
+		if latest == nil {
+			var err error
+			latest, err = c.clusterClient.ClusterV1beta1().PlacementDecisions(placementDecision.Namespace).Get(
+				ctx, placementDecision.Name, metav1.GetOptions{})
+			if err != nil {
+				return err
+			}
+		}
+
+		if equalClusterDecisions(latest.Status.Decisions, clusterDecisions) {
+			return nil
+		}
+
+		patch, err := statusPatch(latest.ObjectMeta, &clusterapiv1beta1.PlacementDecisionStatus{Decisions: clusterDecisions})
+		if err != nil {
+			return err
+		}
+		_, err = c.clusterClient.ClusterV1beta1().PlacementDecisions(placementDecision.Namespace).Patch(
+			ctx, placementDecision.Name, types.MergePatchType, patch, metav1.PatchOptions{FieldManager: statusFieldManager}, "status")
+		switch {
+		case errors.IsConflict(err):
+			latest = nil
+		case err == nil:
+			patched = true
+		}
+		return err
+	})
+	return patched, err
+}
+
+// equalClusterDecisions returns whether the cluster decisions select the same clusters with the
+// same reasons. The scores and the selection in a DecisionReason change from one scheduling to
+// another, e.g. a new cluster becomes steady on the next scheduling, so they are not compared.
+// Otherwise each change of the scores would rewrite the placementdecisions and wake up all their
+// consumers. The reason keeps the scores of the last write until the decisions change.
+func equalClusterDecisions(a, b []clusterapiv1beta1.ClusterDecision) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i].ClusterName != b[i].ClusterName || stableReason(a[i].Reason) != stableReason(b[i].Reason) {
+			return false
+		}
+	}
+	return true
+}
+
+// stableReason returns the filters in the reason if it is a DecisionReason, otherwise the reason.
+func stableReason(reason string) string {
+	decisionReason := DecisionReason{}
+	if err := json.Unmarshal([]byte(reason), &decisionReason); err != nil || decisionReason.Filters == nil {
+		return reason
+	}
+	return "filters:" + strings.Join(decisionReason.Filters, ",")
+}
+
+// statusPatch returns a merge patch which replaces the status of an object. The uid and the
+// resourceVersion of the object are in the patch as preconditions, so the patch fails with a
+// conflict if the object has changed since it was read.
+func statusPatch(objectMeta metav1.ObjectMeta, status interface{}) ([]byte, error) {
+	return json.Marshal(map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"uid":             objectMeta.UID,
+			"resourceVersion": objectMeta.ResourceVersion,
+		},
+		"status": status,
+	})
+}
@@ -0,0 +1,205 @@
+package scheduling
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clienttesting "k8s.io/client-go/testing"
+	clusterfake "open-cluster-management.io/api/client/cluster/clientset/versioned/fake"
+	clusterapiv1beta1 "open-cluster-management.io/api/cluster/v1beta1"
+
+	testinghelpers "open-cluster-management.io/placement/pkg/helpers/testing"
+)
+
+// conflictOnce makes the first patch of the resource fail with a conflict.
+func conflictOnce(clusterClient *clusterfake.Clientset, resource string) {
+	conflicted := false
+	clusterClient.PrependReactor(
+		"patch",
+		resource,
+		func(action clienttesting.Action) (handled bool, ret runtime.Object, err error) {
+			if conflicted {
+				return false, nil, nil
+			}
+			conflicted = true
+			return true, nil, errors.NewConflict(clusterapiv1beta1.Resource(resource), action.(clienttesting.PatchActionImpl).GetName(), nil)
+		},
+	)
+}
+
+func TestPatchPlacementStatus(t *testing.T) {
+	satisfied := metav1.Condition{
+		Type:    clusterapiv1beta1.PlacementConditionSatisfied,
+		Status:  metav1.ConditionTrue,
+		Reason:  "AllDecisionsScheduled",
+		Message: "All cluster decisions scheduled",
+	}
+
+	cases := []struct {
+		name            string
+		placement       *clusterapiv1beta1.Placement
+		conflict        bool
+		condition       metav1.Condition
+		validateActions func(t *testing.T, actions []clienttesting.Action)
+	}{
+		{
+			name:      "patch status",
+			placement: testinghelpers.NewPlacement("ns1", "placement1").Build(),
+			condition: satisfied,
+			validateActions: func(t *testing.T, actions []clienttesting.Action) {
+				testinghelpers.AssertActions(t, actions, "patch")
+				placement := patchedObject(t, actions[0]).(*clusterapiv1beta1.Placement)
+				if placement.Status.NumberOfSelectedClusters != 3 {
+					t.Errorf("expected 3 clusters selected, but got %d", placement.Status.NumberOfSelectedClusters)
+				}
+				if !testinghelpers.HasCondition(placement.Status.Conditions, clusterapiv1beta1.PlacementConditionSatisfied,
+					"AllDecisionsScheduled", metav1.ConditionTrue) {
+					t.Errorf("expected condition satisfied, but got %v", placement.Status.Conditions)
+				}
+			},
+		},
+		{
+			name:      "retry on conflict",
+			placement: testinghelpers.NewPlacement("ns1", "placement1").Build(),
+			conflict:  true,
+			condition: satisfied,
+			validateActions: func(t *testing.T, actions []clienttesting.Action) {
+				testinghelpers.AssertActions(t, actions, "patch", "get", "patch")
+			},
+		},
+		{
+			name:      "no change",
+			placement: testinghelpers.NewPlacement("ns1", "placement1").WithNumOfSelectedClusters(3).Build(),
+			condition: satisfied,
+			validateActions: func(t *testing.T, actions []clienttesting.Action) {
+				testinghelpers.AssertNoActions(t, actions)
+			},
+		},
+		{
+			name:      "patch status with changed reason",
+			placement: testinghelpers.NewPlacement("ns1", "placement1").WithNumOfSelectedClusters(3).Build(),
+			condition: metav1.Condition{
+				Type:    clusterapiv1beta1.PlacementConditionSatisfied,
+				Status:  metav1.ConditionTrue,
+				Reason:  "AllDecisionsScheduledAgain",
+				Message: "All cluster decisions scheduled",
+			},
+			validateActions: func(t *testing.T, actions []clienttesting.Action) {
+				testinghelpers.AssertActions(t, actions, "patch")
+			},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			if c.placement.Status.NumberOfSelectedClusters > 0 {
+				condition := satisfied
+				condition.LastTransitionTime = metav1.Now()
+				c.placement.Status.Conditions = []metav1.Condition{condition}
+			}
+			clusterClient := clusterfake.NewSimpleClientset(c.placement)
+			if c.conflict {
+				conflictOnce(clusterClient, "placements")
+			}
+			ctrl := &schedulingController{clusterClient: clusterClient}
+
+			if err := ctrl.patchPlacementStatus(context.TODO(), c.placement, 3, c.condition); err != nil {
+				t.Errorf("unexpected err: %v", err)
+			}
+			c.validateActions(t, clusterClient.Actions())
+		})
+	}
+}
+
+func TestPatchPlacementDecisionStatus(t *testing.T) {
+	reason := func(score int64, selection string) string {
+		data, _ := json.Marshal(DecisionReason{
+			Filters:      []string{"Predicate"},
+			Score:        score,
+			Prioritizers: map[string]int64{"Balance": score},
+			Selection:    selection,
+		})
+		return string(data)
+	}
+
+	cases := []struct {
+		name              string
+		placementDecision *clusterapiv1beta1.PlacementDecision
+		decisions         []clusterapiv1beta1.ClusterDecision
+		conflict          bool
+		expectedPatched   bool
+		validateActions   func(t *testing.T, actions []clienttesting.Action)
+	}{
+		{
+			name:              "patch decisions",
+			placementDecision: testinghelpers.NewPlacementDecision("ns1", "placement1-decision-1").Build(),
+			decisions:         []clusterapiv1beta1.ClusterDecision{{ClusterName: "cluster1", Reason: "selected"}},
+			expectedPatched:   true,
+			validateActions: func(t *testing.T, actions []clienttesting.Action) {
+				testinghelpers.AssertActions(t, actions, "patch")
+				placementDecision := patchedObject(t, actions[0]).(*clusterapiv1beta1.PlacementDecision)
+				assertClustersSelected(t, placementDecision.Status.Decisions, "cluster1")
+			},
+		},
+		{
+			name:              "retry on conflict",
+			placementDecision: testinghelpers.NewPlacementDecision("ns1", "placement1-decision-1").Build(),
+			decisions:         []clusterapiv1beta1.ClusterDecision{{ClusterName: "cluster1", Reason: "selected"}},
+			conflict:          true,
+			expectedPatched:   true,
+			validateActions: func(t *testing.T, actions []clienttesting.Action) {
+				testinghelpers.AssertActions(t, actions, "patch", "get", "patch")
+			},
+		},
+		{
+			name: "no change",
+			placementDecision: testinghelpers.NewPlacementDecision("ns1", "placement1-decision-1").
+				WithDecisions("cluster1").Build(),
+			decisions:       []clusterapiv1beta1.ClusterDecision{{ClusterName: "cluster1"}},
+			validateActions: testinghelpers.AssertNoActions,
+		},
+		{
+			name: "no change with changed scores and selection",
+			placementDecision: func() *clusterapiv1beta1.PlacementDecision {
+				pd := testinghelpers.NewPlacementDecision("ns1", "placement1-decision-1").Build()
+				pd.Status.Decisions = []clusterapiv1beta1.ClusterDecision{{ClusterName: "cluster1", Reason: reason(0, SelectionNew)}}
+				return pd
+			}(),
+			decisions:       []clusterapiv1beta1.ClusterDecision{{ClusterName: "cluster1", Reason: reason(100, SelectionSteady)}},
+			validateActions: testinghelpers.AssertNoActions,
+		},
+		{
+			name: "patch decisions with changed reason",
+			placementDecision: testinghelpers.NewPlacementDecision("ns1", "placement1-decision-1").
+				WithDecisions("cluster1").Build(),
+			decisions:       []clusterapiv1beta1.ClusterDecision{{ClusterName: "cluster1", Reason: "selected"}},
+			expectedPatched: true,
+			validateActions: func(t *testing.T, actions []clienttesting.Action) {
+				testinghelpers.AssertActions(t, actions, "patch")
+			},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			clusterClient := clusterfake.NewSimpleClientset(c.placementDecision)
+			if c.conflict {
+				conflictOnce(clusterClient, "placementdecisions")
+			}
+			ctrl := &schedulingController{clusterClient: clusterClient}
+
+			patched, err := ctrl.patchPlacementDecisionStatus(context.TODO(), c.placementDecision, c.decisions)
+			if err != nil {
+				t.Errorf("unexpected err: %v", err)
+			}
+			if patched != c.expectedPatched {
+				t.Errorf("expected patched %v, but got %v", c.expectedPatched, patched)
+			}
+			c.validateActions(t, clusterClient.Actions())
+		})
+	}
+}
@@ -0,0 +1,118 @@
+package tracing
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/sdk/resource"
+	semconv "go.opentelemetry.io/otel/semconv/v1.12.0"
+	"go.opentelemetry.io/otel/trace"
+	"k8s.io/component-base/tracing"
+	tracingapiv1 "k8s.io/component-base/tracing/api/v1"
+
+	"open-cluster-management.io/placement/pkg/controllers/framework"
+	"open-cluster-management.io/placement/pkg/version"
+)
+
+const (
+	// TracerName is the name of the tracer of the scheduling spans.
+	TracerName = "open-cluster-management.io/placement"
+
+	serviceName = "placement"
+
+	// maxScoresPerSpan limits the size of the span of a prioritizer on a big hub.
+	maxScoresPerSpan = 100
+)
+
+// The attributes of the scheduling spans.
+const (
+	PlacementNamespaceKey = attribute.Key("placement.namespace")
+	PlacementNameKey      = attribute.Key("placement.name")
+	PluginKey             = attribute.Key("placement.plugin")
+	ClustersKey           = attribute.Key("placement.clusters")
+	FilteredClustersKey   = attribute.Key("placement.filtered_clusters")
+	ClusterSetsKey        = attribute.Key("placement.clustersets")
+	BindingsKey           = attribute.Key("placement.clustersetbindings")
+	DecisionsKey          = attribute.Key("placement.decisions")
+	UnscheduledKey        = attribute.Key("placement.unscheduled_decisions")
+	ScoresKey             = attribute.Key("placement.scores")
+	StatusCodeKey         = attribute.Key("placement.status_code")
+	PlacementDecisionKey  = attribute.Key("placementdecision.name")
+	OperationKey          = attribute.Key("placementdecision.operation")
+)
+
+// Tracer returns the tracer of the scheduling spans from the global tracer provider, so that no
+// span is exported unless a tracer provider is set with NewProvider.
+func Tracer() trace.Tracer {
+	return otel.Tracer(TracerName)
+}
+
+// NewProvider creates a tracer provider which exports the spans over OTLP to the endpoint and
+// sets it as the global tracer provider. The sampling rate is the number of samples per million
+// spans.
+func NewProvider(ctx context.Context, endpoint string, samplingRatePerMillion int32) (tracing.TracerProvider, error) {
+	tp, err := tracing.NewProvider(ctx,
+		&tracingapiv1.TracingConfiguration{
+			Endpoint:               &endpoint,
+			SamplingRatePerMillion: &samplingRatePerMillion,
+		},
+		nil,
+		[]resource.Option{
+			resource.WithAttributes(
+				semconv.ServiceNameKey.String(serviceName),
+				semconv.ServiceVersionKey.String(version.Get().String()),
+			),
+			resource.WithHost(),
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	otel.SetTracerProvider(tp)
+	otel.SetTextMapPropagator(tracing.Propagators())
+	return tp, nil
+}
+
+// RecordStatus sets the code of the framework status on the span. The span is marked as failed
+// if the status is an error.
+func RecordStatus(span trace.Span, status *framework.Status) {
+	span.SetAttributes(StatusCodeKey.Int(int(status.Code())))
+	if len(status.Plugin()) > 0 {
+		span.SetAttributes(PluginKey.String(status.Plugin()))
+	}
+	if status.IsError() {
+		span.SetStatus(codes.Error, status.AsError().Error())
+	}
+}
+
+// RecordError marks the span as failed if the error is not nil.
+func RecordError(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+}
+
+// ScoresAttribute returns the scores of the clusters in the order of cluster names. At most
+// maxScoresPerSpan scores are kept.
+func ScoresAttribute(scores map[string]int64) attribute.KeyValue {
+	names := make([]string, 0, len(scores))
+	for name := range scores {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	if len(names) > maxScoresPerSpan {
+		names = names[:maxScoresPerSpan]
+	}
+
+	values := make([]string, 0, len(names))
+	for _, name := range names {
+		values = append(values, fmt.Sprintf("%s=%d", name, scores[name]))
+	}
+	return ScoresKey.StringSlice(values)
+}
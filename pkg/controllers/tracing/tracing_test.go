@@ -0,0 +1,43 @@
+package tracing
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+func TestScoresAttribute(t *testing.T) {
+	cases := []struct {
+		name     string
+		scores   map[string]int64
+		expected []string
+	}{
+		{
+			name:     "no scores",
+			scores:   map[string]int64{},
+			expected: []string{},
+		},
+		{
+			name:     "sorted by cluster names",
+			scores:   map[string]int64{"cluster2": -10, "cluster1": 100},
+			expected: []string{"cluster1=100", "cluster2=-10"},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			actual := ScoresAttribute(c.scores)
+			if actual.Key != ScoresKey || !reflect.DeepEqual(actual.Value.AsStringSlice(), c.expected) {
+				t.Errorf("expected scores %v, but got %v", c.expected, actual.Value.AsStringSlice())
+			}
+		})
+	}
+
+	scores := map[string]int64{}
+	for i := 0; i < 2*maxScoresPerSpan; i++ {
+		scores[fmt.Sprintf("cluster%03d", i)] = int64(i)
+	}
+	if actual := ScoresAttribute(scores).Value.AsStringSlice(); len(actual) != maxScoresPerSpan {
+		t.Errorf("expected %d scores, but got %d", maxScoresPerSpan, len(actual))
+	}
+}
@@ -0,0 +1,149 @@
+package debugger
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+
+	clusterapiv1beta1 "open-cluster-management.io/api/cluster/v1beta1"
+	scheduling "open-cluster-management.io/placement/pkg/controllers/scheduling"
+)
+
+// ClusterDebugPath is the path of the placements selecting a cluster, in the form of
+// /debug/clusters/<name>/placements.
+const ClusterDebugPath = "/debug/clusters/"
+
+const clusterPlacementsSubpath = "placements"
+
+// ClusterPlacementsResult is the result returned by the debugger for a cluster.
+type ClusterPlacementsResult struct {
+	// Cluster is the name of the cluster.
+	Cluster string `json:"cluster"`
+	// Placements are the placements which currently select the cluster, in the order of
+	// namespace/name.
+	Placements []SelectingPlacement `json:"placements"`
+	Error      string               `json:"error,omitempty"`
+}
+
+// SelectingPlacement is a placement which has the cluster in its decisions.
+type SelectingPlacement struct {
+	Namespace string `json:"namespace"`
+	Name      string `json:"name"`
+	// PlacementDecision is the name of the placementdecision containing the cluster.
+	PlacementDecision string `json:"placementDecision"`
+	// Score is the total score of the cluster when it was selected. It is not set if the reason
+	// of the decision does not record the score.
+	Score *int64 `json:"score,omitempty"`
+	// Selection is Steady if the cluster was kept from the previous decisions, otherwise New.
+	Selection string `json:"selection,omitempty"`
+	// Prioritizers contains the weighted score each prioritizer contributed to the total score.
+	Prioritizers map[string]int64 `json:"prioritizers,omitempty"`
+	// Reason is the reason of the decision as it is written in the placementdecision.
+	Reason string `json:"reason"`
+}
+
+// ClusterHandler returns the placements which select the cluster for a GET request. The
+// placementdecisions are looked up with the index by cluster, so no placementdecision is listed.
+func (d *Debugger) ClusterHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		d.reportClusterErr(w, "", fmt.Errorf("method %s is not allowed", r.Method))
+		return
+	}
+
+	clusterName, err := parseClusterPath(r.URL.Path)
+	if err != nil {
+		d.reportClusterErr(w, "", err)
+		return
+	}
+
+	objs, err := d.placementDecisionIndexer.ByIndex(scheduling.PlacementDecisionsByCluster, clusterName)
+	if err != nil {
+		d.reportClusterErr(w, clusterName, err)
+		return
+	}
+
+	placementDecisions := []*clusterapiv1beta1.PlacementDecision{}
+	for _, o := range objs {
+		placementDecisions = append(placementDecisions, o.(*clusterapiv1beta1.PlacementDecision))
+	}
+
+	resultByte, _ := json.Marshal(ClusterPlacements(clusterName, placementDecisions))
+
+	w.Write(resultByte)
+}
+
+// ClusterPlacements returns the placements which have the cluster in the placementdecisions.
+// The placementdecisions which do not belong to any placement are ignored.
+func ClusterPlacements(clusterName string, placementDecisions []*clusterapiv1beta1.PlacementDecision) ClusterPlacementsResult {
+	result := ClusterPlacementsResult{
+		Cluster:    clusterName,
+		Placements: []SelectingPlacement{},
+	}
+
+	for _, pd := range placementDecisions {
+		placementName, ok := pd.Labels[clusterapiv1beta1.PlacementLabel]
+		if !ok {
+			continue
+		}
+		for _, decision := range pd.Status.Decisions {
+			if decision.ClusterName != clusterName {
+				continue
+			}
+			result.Placements = append(result.Placements, selectingPlacement(pd.Namespace, placementName, pd.Name, decision))
+		}
+	}
+
+	sort.Slice(result.Placements, func(i, j int) bool {
+		pi, pj := result.Placements[i], result.Placements[j]
+		if pi.Namespace != pj.Namespace {
+			return pi.Namespace < pj.Namespace
+		}
+		if pi.Name != pj.Name {
+			return pi.Name < pj.Name
+		}
+		return pi.PlacementDecision < pj.PlacementDecision
+	})
+	return result
+}
+
+// selectingPlacement builds the SelectingPlacement of a decision. The score, the selection and
+// the prioritizers are read from the reason if it is a scheduling.DecisionReason.
+func selectingPlacement(namespace, name, placementDecision string, decision clusterapiv1beta1.ClusterDecision) SelectingPlacement {
+	p := SelectingPlacement{
+		Namespace:         namespace,
+		Name:              name,
+		PlacementDecision: placementDecision,
+		Reason:            decision.Reason,
+	}
+
+	reason := scheduling.DecisionReason{}
+	if err := json.Unmarshal([]byte(decision.Reason), &reason); err != nil {
+		return p
+	}
+	score := reason.Score
+	p.Score = &score
+	p.Selection = reason.Selection
+	p.Prioritizers = reason.Prioritizers
+	return p
+}
+
+// parseClusterPath returns the cluster name of a path in the form of
+// /debug/clusters/<name>/placements.
+func parseClusterPath(path string) (string, error) {
+	parts := strings.Split(strings.TrimPrefix(path, ClusterDebugPath), "/")
+	if len(parts) != 2 || len(parts[0]) == 0 || parts[1] != clusterPlacementsSubpath {
+		return "", fmt.Errorf("invalid path %q, must be %s<name>/%s", path, ClusterDebugPath, clusterPlacementsSubpath)
+	}
+	return parts[0], nil
+}
+
+func (d *Debugger) reportClusterErr(w http.ResponseWriter, clusterName string, err error) {
+	result := &ClusterPlacementsResult{Cluster: clusterName, Error: err.Error()}
+
+	resultByte, _ := json.Marshal(result)
+
+	w.Write(resultByte)
+}
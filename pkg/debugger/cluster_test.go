@@ -0,0 +1,135 @@
+package debugger
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	clusterfake "open-cluster-management.io/api/client/cluster/clientset/versioned/fake"
+	clusterapiv1beta1 "open-cluster-management.io/api/cluster/v1beta1"
+	scheduling "open-cluster-management.io/placement/pkg/controllers/scheduling"
+	testinghelpers "open-cluster-management.io/placement/pkg/helpers/testing"
+)
+
+func newDecision(namespace, name, placement string, decisions ...clusterapiv1beta1.ClusterDecision) *clusterapiv1beta1.PlacementDecision {
+	pd := testinghelpers.NewPlacementDecision(namespace, name).Build()
+	if len(placement) > 0 {
+		pd.Labels = map[string]string{clusterapiv1beta1.PlacementLabel: placement}
+	}
+	pd.Status.Decisions = decisions
+	return pd
+}
+
+func TestClusterHandler(t *testing.T) {
+	reason, _ := json.Marshal(scheduling.DecisionReason{
+		Filters:      []string{"Predicate"},
+		Score:        120,
+		Prioritizers: map[string]int64{"Balance": 100, "Steady": 20},
+		Selection:    scheduling.SelectionSteady,
+	})
+	score := int64(120)
+
+	placementDecisions := []*clusterapiv1beta1.PlacementDecision{
+		newDecision("ns2", "placement2-decision-1", "placement2",
+			clusterapiv1beta1.ClusterDecision{ClusterName: "cluster1", Reason: "selected"}),
+		newDecision("ns1", "placement1-decision-1", "placement1",
+			clusterapiv1beta1.ClusterDecision{ClusterName: "cluster2", Reason: string(reason)}),
+		newDecision("ns1", "placement1-decision-2", "placement1",
+			clusterapiv1beta1.ClusterDecision{ClusterName: "cluster1", Reason: string(reason)}),
+		newDecision("ns1", "orphan", "",
+			clusterapiv1beta1.ClusterDecision{ClusterName: "cluster1", Reason: string(reason)}),
+	}
+
+	cases := []struct {
+		name           string
+		path           string
+		expectedResult ClusterPlacementsResult
+	}{
+		{
+			name: "cluster selected by placements",
+			path: ClusterDebugPath + "cluster1/placements",
+			expectedResult: ClusterPlacementsResult{
+				Cluster: "cluster1",
+				Placements: []SelectingPlacement{
+					{
+						Namespace:         "ns1",
+						Name:              "placement1",
+						PlacementDecision: "placement1-decision-2",
+						Score:             &score,
+						Selection:         scheduling.SelectionSteady,
+						Prioritizers:      map[string]int64{"Balance": 100, "Steady": 20},
+						Reason:            string(reason),
+					},
+					{
+						Namespace:         "ns2",
+						Name:              "placement2",
+						PlacementDecision: "placement2-decision-1",
+						Reason:            "selected",
+					},
+				},
+			},
+		},
+		{
+			name: "cluster not selected",
+			path: ClusterDebugPath + "cluster3/placements",
+			expectedResult: ClusterPlacementsResult{
+				Cluster:    "cluster3",
+				Placements: []SelectingPlacement{},
+			},
+		},
+		{
+			name: "invalid path",
+			path: ClusterDebugPath + "cluster1",
+			expectedResult: ClusterPlacementsResult{
+				Error: fmt.Sprintf("invalid path %q, must be %s<name>/placements", ClusterDebugPath+"cluster1", ClusterDebugPath),
+			},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			clusterClient := clusterfake.NewSimpleClientset()
+			clusterInformerFactory := testinghelpers.NewClusterInformerFactory(clusterClient)
+			debugger := NewDebugger(
+				&testScheduler{},
+				clusterInformerFactory.Cluster().V1beta1().Placements(),
+				clusterInformerFactory.Cluster().V1().ManagedClusters(),
+				clusterInformerFactory.Cluster().V1beta2().ManagedClusterSets(),
+				clusterInformerFactory.Cluster().V1beta2().ManagedClusterSetBindings(),
+				clusterInformerFactory.Cluster().V1beta1().PlacementDecisions(),
+			)
+			// the placementdecisions are added after the index is added by the debugger
+			placementDecisionStore := clusterInformerFactory.Cluster().V1beta1().PlacementDecisions().Informer().GetStore()
+			for _, pd := range placementDecisions {
+				if err := placementDecisionStore.Add(pd); err != nil {
+					t.Fatalf("unexpected err: %v", err)
+				}
+			}
+
+			server := httptest.NewServer(http.HandlerFunc(debugger.ClusterHandler))
+			defer server.Close()
+			res, err := http.Get(server.URL + c.path)
+			if err != nil {
+				t.Fatalf("Expect no error but get %v", err)
+			}
+
+			responseBody, err := ioutil.ReadAll(res.Body)
+			if err != nil {
+				t.Fatalf("Unexpected error reading response body: %v", err)
+			}
+
+			result := ClusterPlacementsResult{}
+			if err := json.Unmarshal(responseBody, &result); err != nil {
+				t.Fatalf("Unexpected error unmarshaling result: %v", err)
+			}
+
+			if !reflect.DeepEqual(result, c.expectedResult) {
+				t.Errorf("expected result %+v, but got %+v", c.expectedResult, result)
+			}
+		})
+	}
+}
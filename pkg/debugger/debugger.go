@@ -1,16 +1,23 @@
 package debugger
 
 import (
+	"context"
 	"encoding/json"
+	"fmt"
 	"net/http"
 	"strings"
 
-	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/client-go/tools/cache"
 	clusterinformerv1 "open-cluster-management.io/api/client/cluster/informers/externalversions/cluster/v1"
 	clusterinformerv1beta1 "open-cluster-management.io/api/client/cluster/informers/externalversions/cluster/v1beta1"
+	clusterinformerv1beta2 "open-cluster-management.io/api/client/cluster/informers/externalversions/cluster/v1beta2"
 	clusterlisterv1 "open-cluster-management.io/api/client/cluster/listers/cluster/v1"
 	clusterlisterv1beta1 "open-cluster-management.io/api/client/cluster/listers/cluster/v1beta1"
+	clusterlisterv1beta2 "open-cluster-management.io/api/client/cluster/listers/cluster/v1beta2"
+	clusterapiv1 "open-cluster-management.io/api/cluster/v1"
+	clusterapiv1beta1 "open-cluster-management.io/api/cluster/v1beta1"
 	scheduling "open-cluster-management.io/placement/pkg/controllers/scheduling"
 )
 
@@ -18,30 +25,71 @@ const DebugPath = "/debug/placements/"
 
 // Debugger provides a debug http endpoint for scheduler
 type Debugger struct {
-	scheduler       scheduling.Scheduler
-	clusterLister   clusterlisterv1.ManagedClusterLister
-	placementLister clusterlisterv1beta1.PlacementLister
+	scheduler               scheduling.Scheduler
+	clusterLister           clusterlisterv1.ManagedClusterLister
+	clusterSetLister        clusterlisterv1beta2.ManagedClusterSetLister
+	clusterSetBindingLister clusterlisterv1beta2.ManagedClusterSetBindingLister
+	placementLister         clusterlisterv1beta1.PlacementLister
+	placementDecisionLister clusterlisterv1beta1.PlacementDecisionLister
+	// placementDecisionIndexer indexes the placementdecisions by the clusters in their decisions.
+	placementDecisionIndexer cache.Indexer
 }
 
 // DebugResult is the result returned by debugger
 type DebugResult struct {
+	// ClusterSets are the names of the clustersets eligible for the placement.
+	ClusterSets       []string                       `json:"eligibleClusterSets,omitempty"`
 	FilterResults     []scheduling.FilterResult      `json:"filteredPiplieResults,omitempty"`
 	PrioritizeResults []scheduling.PrioritizerResult `json:"prioritizeResults,omitempty"`
-	Error             string                         `json:"error,omitempty"`
+	// Scores are the total scores of the feasible clusters.
+	Scores    scheduling.PrioritizerScore         `json:"scores,omitempty"`
+	Decisions []clusterapiv1beta1.ClusterDecision `json:"decisions,omitempty"`
+	// NumOfUnscheduled is the number of the decisions which could not be scheduled.
+	NumOfUnscheduled int `json:"numOfUnscheduled,omitempty"`
+	// RequeueAfter is how long after the placement will be scheduled again, if it is set.
+	RequeueAfter string `json:"requeueAfter,omitempty"`
+	// RejectedClusters explains why each eligible cluster is filtered out.
+	RejectedClusters map[string]string `json:"rejectedClusters,omitempty"`
+	Error            string            `json:"error,omitempty"`
 }
 
 func NewDebugger(
 	scheduler scheduling.Scheduler,
 	placementInformer clusterinformerv1beta1.PlacementInformer,
-	clusterInformer clusterinformerv1.ManagedClusterInformer) *Debugger {
+	clusterInformer clusterinformerv1.ManagedClusterInformer,
+	clusterSetInformer clusterinformerv1beta2.ManagedClusterSetInformer,
+	clusterSetBindingInformer clusterinformerv1beta2.ManagedClusterSetBindingInformer,
+	placementDecisionInformer clusterinformerv1beta1.PlacementDecisionInformer) *Debugger {
+	if err := scheduling.AddPlacementDecisionIndexer(placementDecisionInformer); err != nil {
+		runtime.HandleError(err)
+	}
+
 	return &Debugger{
-		scheduler:       scheduler,
-		clusterLister:   clusterInformer.Lister(),
-		placementLister: placementInformer.Lister(),
+		scheduler:                scheduler,
+		clusterLister:            clusterInformer.Lister(),
+		clusterSetLister:         clusterSetInformer.Lister(),
+		clusterSetBindingLister:  clusterSetBindingInformer.Lister(),
+		placementLister:          placementInformer.Lister(),
+		placementDecisionLister:  placementDecisionInformer.Lister(),
+		placementDecisionIndexer: placementDecisionInformer.Informer().GetIndexer(),
 	}
 }
 
+// Handler returns the schedule result of an existing placement for a GET request, and of a
+// hypothetical placement or cluster changes for a POST request with a WhatIfRequest body.
 func (d *Debugger) Handler(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		d.placementHandler(w, r)
+	case http.MethodPost:
+		d.whatIfHandler(w, r)
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		d.reportErr(w, fmt.Errorf("method %s is not allowed", r.Method))
+	}
+}
+
+func (d *Debugger) placementHandler(w http.ResponseWriter, r *http.Request) {
 	namespace, name, err := d.parsePath(r.URL.Path)
 	if err != nil {
 		d.reportErr(w, err)
@@ -54,21 +102,73 @@ func (d *Debugger) Handler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	clusters, err := d.clusterLister.List(labels.Everything())
+	result, err := d.schedule(r.Context(), placement, d.clusterLister)
 	if err != nil {
 		d.reportErr(w, err)
 		return
 	}
 
-	scheduleResults, _ := d.scheduler.Schedule(r.Context(), placement, clusters)
-
-	result := DebugResult{FilterResults: scheduleResults.FilterResults(), PrioritizeResults: scheduleResults.PrioritizerResults()}
-
 	resultByte, _ := json.Marshal(result)
 
 	w.Write(resultByte)
 }
 
+// schedule schedules the placement with the clusters resolved in the same way as the
+// scheduling controller. The scheduling cycle works on a snapshot of its own, and the decisions
+// are not reserved or written, so the state of the controller is not changed and the scheduling
+// lock of the controller is not needed.
+func (d *Debugger) schedule(ctx context.Context, placement *clusterapiv1beta1.Placement, clusterLister clusterlisterv1.ManagedClusterLister) (DebugResult, error) {
+	eligible, err := scheduling.GetEligibleClusters(clusterLister, d.clusterSetLister, d.clusterSetBindingLister, placement)
+	if err != nil {
+		return DebugResult{}, err
+	}
+
+	scheduleResults, status := d.scheduler.Schedule(ctx, placement, eligible.Clusters)
+
+	result := DebugResult{
+		ClusterSets:       eligible.ClusterSets,
+		FilterResults:     scheduleResults.FilterResults(),
+		PrioritizeResults: scheduleResults.PrioritizerResults(),
+		Scores:            scheduleResults.PrioritizerScores(),
+		Decisions:         scheduleResults.Decisions(),
+		NumOfUnscheduled:  scheduleResults.NumOfUnscheduled(),
+		RejectedClusters:  rejectedClusters(eligible.Clusters, scheduleResults.FilterResults()),
+	}
+	if requeueAfter := scheduleResults.RequeueAfter(); requeueAfter != nil {
+		result.RequeueAfter = requeueAfter.String()
+	}
+	if status.IsError() {
+		result.Error = status.AsError().Error()
+	}
+	return result, nil
+}
+
+// rejectedClusters returns the filter which filters out each cluster, with the reason if the
+// filter reports it.
+func rejectedClusters(clusters []*clusterapiv1.ManagedCluster, filterResults []scheduling.FilterResult) map[string]string {
+	remaining := sets.NewString()
+	for _, cluster := range clusters {
+		remaining.Insert(cluster.Name)
+	}
+
+	rejected := map[string]string{}
+	for _, r := range filterResults {
+		names := strings.Split(r.Name, ",")
+		filter := names[len(names)-1]
+
+		filtered := sets.NewString(r.FilteredClusters...)
+		for _, cluster := range remaining.Difference(filtered).List() {
+			if reason, ok := r.RejectedClusters[cluster]; ok {
+				rejected[cluster] = fmt.Sprintf("%s: %s", filter, reason)
+			} else {
+				rejected[cluster] = fmt.Sprintf("filtered out by %s", filter)
+			}
+		}
+		remaining = remaining.Intersection(filtered)
+	}
+	return rejected
+}
+
 func (d *Debugger) parsePath(path string) (string, string, error) {
 	metaNamespaceKey := strings.TrimPrefix(path, DebugPath)
 	return cache.SplitMetaNamespaceKey(metaNamespaceKey)
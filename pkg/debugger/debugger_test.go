@@ -8,6 +8,8 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"reflect"
+	"sort"
+	"strings"
 	"testing"
 	"time"
 
@@ -15,19 +17,26 @@ import (
 	clusterfake "open-cluster-management.io/api/client/cluster/clientset/versioned/fake"
 	clusterapiv1 "open-cluster-management.io/api/cluster/v1"
 	clusterapiv1beta1 "open-cluster-management.io/api/cluster/v1beta1"
+	clusterapiv1beta2 "open-cluster-management.io/api/cluster/v1beta2"
 	"open-cluster-management.io/placement/pkg/controllers/framework"
 	scheduling "open-cluster-management.io/placement/pkg/controllers/scheduling"
 	testinghelpers "open-cluster-management.io/placement/pkg/helpers/testing"
 )
 
 type testScheduler struct {
-	result *testResult
+	ctx       context.Context
+	result    *testResult
+	status    *framework.Status
+	clusters  []string
+	placement *clusterapiv1beta1.Placement
+	scheduled map[string]*clusterapiv1.ManagedCluster
 }
 
 type testResult struct {
 	filterResults     []scheduling.FilterResult
 	prioritizeResults []scheduling.PrioritizerResult
 	scoreSum          scheduling.PrioritizerScore
+	decisions         []clusterapiv1beta1.ClusterDecision
 }
 
 func (r *testResult) FilterResults() []scheduling.FilterResult {
@@ -43,7 +52,7 @@ func (r *testResult) PrioritizerScores() scheduling.PrioritizerScore {
 }
 
 func (r *testResult) Decisions() []clusterapiv1beta1.ClusterDecision {
-	return []clusterapiv1beta1.ClusterDecision{}
+	return r.decisions
 }
 
 func (r *testResult) NumOfUnscheduled() int {
@@ -54,7 +63,16 @@ func (s *testScheduler) Schedule(ctx context.Context,
 	placement *clusterapiv1beta1.Placement,
 	clusters []*clusterapiv1.ManagedCluster,
 ) (scheduling.ScheduleResult, *framework.Status) {
-	return s.result, nil
+	s.ctx = ctx
+	s.placement = placement
+	s.clusters = []string{}
+	s.scheduled = map[string]*clusterapiv1.ManagedCluster{}
+	for _, cluster := range clusters {
+		s.clusters = append(s.clusters, cluster.Name)
+		s.scheduled[cluster.Name] = cluster
+	}
+	sort.Strings(s.clusters)
+	return s.result, s.status
 }
 
 func (r *testResult) RequeueAfter() *time.Duration {
@@ -67,22 +85,62 @@ func TestDebugger(t *testing.T) {
 	placementName := "test"
 
 	cases := []struct {
-		name              string
-		initObjs          []runtime.Object
-		filterResults     []scheduling.FilterResult
-		prioritizeResults []scheduling.PrioritizerResult
-		key               string
+		name                     string
+		initObjs                 []runtime.Object
+		filterResults            []scheduling.FilterResult
+		prioritizeResults        []scheduling.PrioritizerResult
+		decisions                []clusterapiv1beta1.ClusterDecision
+		status                   *framework.Status
+		key                      string
+		expectedClusters         []string
+		expectedClusterSets      []string
+		expectedRejectedClusters map[string]string
+		expectedError            string
 	}{
 		{
 			name: "A valid placement",
 			initObjs: []runtime.Object{
 				testinghelpers.NewPlacement(placementNamespace, placementName).Build(),
-				testinghelpers.NewManagedCluster("cluster1").Build(),
-				testinghelpers.NewManagedCluster("cluster2").Build(),
+				testinghelpers.NewClusterSet("clusterset1").Build(),
+				testinghelpers.NewClusterSetBinding(placementNamespace, "clusterset1"),
+				testinghelpers.NewManagedCluster("cluster1").WithLabel(clusterapiv1beta2.ClusterSetLabel, "clusterset1").Build(),
+				testinghelpers.NewManagedCluster("cluster2").WithLabel(clusterapiv1beta2.ClusterSetLabel, "clusterset1").Build(),
+				testinghelpers.NewManagedCluster("cluster3").WithLabel(clusterapiv1beta2.ClusterSetLabel, "clusterset1").Build(),
+				testinghelpers.NewManagedCluster("cluster4").Build(),
+			},
+			filterResults: []scheduling.FilterResult{
+				{Name: "filter1", FilteredClusters: []string{"cluster1", "cluster2"}},
+				{Name: "filter1,filter2", FilteredClusters: []string{"cluster1"}, RejectedClusters: map[string]string{"cluster2": "not matched"}},
+			},
+			prioritizeResults:   []scheduling.PrioritizerResult{{Name: "prioritize1", Scores: map[string]int64{"cluster1": 100}}},
+			decisions:           []clusterapiv1beta1.ClusterDecision{{ClusterName: "cluster1"}},
+			key:                 placementNamespace + "/" + placementName,
+			expectedClusters:    []string{"cluster1", "cluster2", "cluster3"},
+			expectedClusterSets: []string{"clusterset1"},
+			expectedRejectedClusters: map[string]string{
+				"cluster2": "filter2: not matched",
+				"cluster3": "filtered out by filter1",
+			},
+		},
+		{
+			name: "A placement without bound clustersets",
+			initObjs: []runtime.Object{
+				testinghelpers.NewPlacement(placementNamespace, placementName).Build(),
+				testinghelpers.NewClusterSet("clusterset1").Build(),
+				testinghelpers.NewManagedCluster("cluster1").WithLabel(clusterapiv1beta2.ClusterSetLabel, "clusterset1").Build(),
+			},
+			key:              placementNamespace + "/" + placementName,
+			expectedClusters: []string{},
+		},
+		{
+			name: "A misconfigured placement",
+			initObjs: []runtime.Object{
+				testinghelpers.NewPlacement(placementNamespace, placementName).Build(),
 			},
-			filterResults:     []scheduling.FilterResult{{Name: "filter1", FilteredClusters: []string{"cluster1", "cluster2"}}},
-			prioritizeResults: []scheduling.PrioritizerResult{{Name: "prioritize1", Scores: map[string]int64{"cluster1": 100, "cluster2": 0}}},
-			key:               placementNamespace + "/" + placementName,
+			status:           framework.NewStatus("filter1", framework.Misconfigured, "invalid predicate"),
+			key:              placementNamespace + "/" + placementName,
+			expectedClusters: []string{},
+			expectedError:    "invalid predicate",
 		},
 	}
 
@@ -90,9 +148,18 @@ func TestDebugger(t *testing.T) {
 		t.Run(c.name, func(t *testing.T) {
 			clusterClient := clusterfake.NewSimpleClientset(c.initObjs...)
 			clusterInformerFactory := testinghelpers.NewClusterInformerFactory(clusterClient, c.initObjs...)
-			s := &testScheduler{result: &testResult{filterResults: c.filterResults, prioritizeResults: c.prioritizeResults}}
+			s := &testScheduler{
+				result: &testResult{filterResults: c.filterResults, prioritizeResults: c.prioritizeResults, decisions: c.decisions},
+				status: c.status,
+			}
 			debugger := NewDebugger(
-				s, clusterInformerFactory.Cluster().V1beta1().Placements(), clusterInformerFactory.Cluster().V1().ManagedClusters())
+				s,
+				clusterInformerFactory.Cluster().V1beta1().Placements(),
+				clusterInformerFactory.Cluster().V1().ManagedClusters(),
+				clusterInformerFactory.Cluster().V1beta2().ManagedClusterSets(),
+				clusterInformerFactory.Cluster().V1beta2().ManagedClusterSetBindings(),
+				clusterInformerFactory.Cluster().V1beta1().PlacementDecisions(),
+			)
 			server := httptest.NewServer(http.HandlerFunc(debugger.Handler))
 			res, err := http.Get(fmt.Sprintf("%s%s%s", server.URL, DebugPath, c.key))
 
@@ -119,6 +186,26 @@ func TestDebugger(t *testing.T) {
 				t.Errorf("Expect prioritize result to be: %v. but got: %v", c.prioritizeResults, result.PrioritizeResults)
 			}
 
+			if !reflect.DeepEqual(s.clusters, c.expectedClusters) {
+				t.Errorf("Expect clusters scheduled to be: %v. but got: %v", c.expectedClusters, s.clusters)
+			}
+
+			if !reflect.DeepEqual(result.ClusterSets, c.expectedClusterSets) {
+				t.Errorf("Expect eligible clustersets to be: %v. but got: %v", c.expectedClusterSets, result.ClusterSets)
+			}
+
+			if !reflect.DeepEqual(result.Decisions, c.decisions) {
+				t.Errorf("Expect decisions to be: %v. but got: %v", c.decisions, result.Decisions)
+			}
+
+			if !reflect.DeepEqual(result.RejectedClusters, c.expectedRejectedClusters) {
+				t.Errorf("Expect rejected clusters to be: %v. but got: %v", c.expectedRejectedClusters, result.RejectedClusters)
+			}
+
+			if !strings.Contains(result.Error, c.expectedError) || (len(c.expectedError) == 0 && len(result.Error) > 0) {
+				t.Errorf("Expect error to be: %q. but got: %q", c.expectedError, result.Error)
+			}
+
 			server.Close()
 		})
 	}
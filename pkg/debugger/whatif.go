@@ -0,0 +1,227 @@
+package debugger
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/sets"
+	clusterlisterv1 "open-cluster-management.io/api/client/cluster/listers/cluster/v1"
+	clusterapiv1 "open-cluster-management.io/api/cluster/v1"
+	clusterapiv1beta1 "open-cluster-management.io/api/cluster/v1beta1"
+	"open-cluster-management.io/placement/pkg/plugins"
+	"open-cluster-management.io/placement/pkg/plugins/predicate"
+)
+
+// WhatIfRequest is the body of a POST request to the debugger. The placement is scheduled in
+// dry-run mode with the overrides applied to the clusters, and nothing is written to the hub.
+type WhatIfRequest struct {
+	// Placement is a placement which is not necessarily stored in the hub. Its namespace and
+	// name are set from the request path. The placement in the hub is used if it is not set.
+	// +optional
+	Placement *clusterapiv1beta1.Placement `json:"placement,omitempty"`
+
+	// Overrides change the clusters for the scheduling only.
+	// +optional
+	Overrides ClusterOverrides `json:"overrides,omitempty"`
+}
+
+// ClusterOverrides are hypothetical changes of the clusters.
+type ClusterOverrides struct {
+	// RemovedClusters are the names of the clusters treated as removed from the hub.
+	// +optional
+	RemovedClusters []string `json:"removedClusters,omitempty"`
+
+	// Taints are the taints added to the clusters, by cluster name.
+	// +optional
+	Taints map[string][]clusterapiv1.Taint `json:"taints,omitempty"`
+
+	// Labels are the labels set on the clusters, by cluster name. A label with a null value is
+	// removed from the cluster.
+	// +optional
+	Labels map[string]map[string]*string `json:"labels,omitempty"`
+}
+
+// WhatIfResult is the result returned by the debugger for a POST request.
+type WhatIfResult struct {
+	DebugResult
+
+	// Diff compares the decisions with the current decisions of the placement. It is set only
+	// if the placement exists in the hub.
+	Diff *DecisionDiff `json:"diff,omitempty"`
+}
+
+// DecisionDiff is the difference between the decisions and the current decisions.
+type DecisionDiff struct {
+	// Added are the clusters which are selected but not in the current decisions.
+	Added []string `json:"added"`
+	// Removed are the clusters which are in the current decisions but not selected.
+	Removed []string `json:"removed"`
+	// Unchanged are the clusters which are selected and in the current decisions.
+	Unchanged []string `json:"unchanged"`
+}
+
+func (d *Debugger) whatIfHandler(w http.ResponseWriter, r *http.Request) {
+	namespace, name, err := d.parsePath(r.URL.Path)
+	if err != nil {
+		d.reportErr(w, err)
+		return
+	}
+
+	request := &WhatIfRequest{}
+	if err := json.NewDecoder(r.Body).Decode(request); err != nil {
+		d.reportErr(w, fmt.Errorf("invalid request: %v", err))
+		return
+	}
+
+	existing, err := d.placementLister.Placements(namespace).Get(name)
+	switch {
+	case errors.IsNotFound(err) && request.Placement != nil:
+		existing = nil
+	case err != nil:
+		d.reportErr(w, err)
+		return
+	}
+
+	placement := existing
+	if request.Placement != nil {
+		// the placement is not validated by the API server
+		if err := validatePlacement(request.Placement); err != nil {
+			d.reportErr(w, fmt.Errorf("invalid placement: %v", err))
+			return
+		}
+		placement = request.Placement.DeepCopy()
+		placement.Namespace = namespace
+		placement.Name = name
+	}
+
+	// the plugins get the clusters with the overrides as well
+	clusterLister := &overriddenClusterLister{
+		ManagedClusterLister: d.clusterLister,
+		overrides:            request.Overrides,
+	}
+	ctx := plugins.WithClusterLister(r.Context(), clusterLister)
+	debugResult, err := d.schedule(ctx, placement, clusterLister)
+	if err != nil {
+		d.reportErr(w, err)
+		return
+	}
+
+	result := WhatIfResult{DebugResult: debugResult}
+	if existing != nil {
+		currentDecisions, err := d.currentDecisions(namespace, name)
+		if err != nil {
+			d.reportErr(w, err)
+			return
+		}
+		result.Diff = diffDecisions(currentDecisions, debugResult.Decisions)
+	}
+
+	resultByte, _ := json.Marshal(result)
+
+	w.Write(resultByte)
+}
+
+// validatePlacement returns an error if the number of clusters or the predicates of the placement
+// are invalid.
+func validatePlacement(placement *clusterapiv1beta1.Placement) error {
+	if placement.Spec.NumberOfClusters != nil && *placement.Spec.NumberOfClusters < 0 {
+		return fmt.Errorf("numberOfClusters must not be negative, but got %d", *placement.Spec.NumberOfClusters)
+	}
+	return predicate.Validate(placement)
+}
+
+// currentDecisions returns the names of the clusters in the placementdecisions of the placement.
+func (d *Debugger) currentDecisions(namespace, name string) (sets.String, error) {
+	selector := labels.SelectorFromSet(labels.Set{clusterapiv1beta1.PlacementLabel: name})
+	placementDecisions, err := d.placementDecisionLister.PlacementDecisions(namespace).List(selector)
+	if err != nil {
+		return nil, err
+	}
+
+	clusterNames := sets.NewString()
+	for _, pd := range placementDecisions {
+		for _, decision := range pd.Status.Decisions {
+			clusterNames.Insert(decision.ClusterName)
+		}
+	}
+	return clusterNames, nil
+}
+
+func diffDecisions(current sets.String, decisions []clusterapiv1beta1.ClusterDecision) *DecisionDiff {
+	selected := sets.NewString()
+	for _, decision := range decisions {
+		selected.Insert(decision.ClusterName)
+	}
+
+	return &DecisionDiff{
+		Added:     selected.Difference(current).List(),
+		Removed:   current.Difference(selected).List(),
+		Unchanged: selected.Intersection(current).List(),
+	}
+}
+
+// overriddenClusterLister lists the clusters with the overrides applied, so that the clusterset
+// membership is resolved with the overridden labels as well.
+type overriddenClusterLister struct {
+	clusterlisterv1.ManagedClusterLister
+	overrides ClusterOverrides
+}
+
+func (l *overriddenClusterLister) List(selector labels.Selector) ([]*clusterapiv1.ManagedCluster, error) {
+	clusters, err := l.ManagedClusterLister.List(labels.Everything())
+	if err != nil {
+		return nil, err
+	}
+
+	removed := sets.NewString(l.overrides.RemovedClusters...)
+	result := []*clusterapiv1.ManagedCluster{}
+	for _, cluster := range clusters {
+		if removed.Has(cluster.Name) {
+			continue
+		}
+		cluster = l.override(cluster)
+		if selector.Matches(labels.Set(cluster.Labels)) {
+			result = append(result, cluster)
+		}
+	}
+	return result, nil
+}
+
+func (l *overriddenClusterLister) Get(name string) (*clusterapiv1.ManagedCluster, error) {
+	if sets.NewString(l.overrides.RemovedClusters...).Has(name) {
+		return nil, errors.NewNotFound(clusterapiv1.Resource("managedcluster"), name)
+	}
+
+	cluster, err := l.ManagedClusterLister.Get(name)
+	if err != nil {
+		return nil, err
+	}
+	return l.override(cluster), nil
+}
+
+// override returns a copy of the cluster with the overrides applied, or the cluster itself if
+// there is no override of it.
+func (l *overriddenClusterLister) override(cluster *clusterapiv1.ManagedCluster) *clusterapiv1.ManagedCluster {
+	taints, hasTaints := l.overrides.Taints[cluster.Name]
+	labelChanges, hasLabels := l.overrides.Labels[cluster.Name]
+	if !hasTaints && !hasLabels {
+		return cluster
+	}
+
+	cluster = cluster.DeepCopy()
+	cluster.Spec.Taints = append(cluster.Spec.Taints, taints...)
+	if cluster.Labels == nil {
+		cluster.Labels = map[string]string{}
+	}
+	for key, value := range labelChanges {
+		if value == nil {
+			delete(cluster.Labels, key)
+			continue
+		}
+		cluster.Labels[key] = *value
+	}
+	return cluster
+}
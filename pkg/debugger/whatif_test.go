@@ -0,0 +1,208 @@
+package debugger
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clusterfake "open-cluster-management.io/api/client/cluster/clientset/versioned/fake"
+	clusterapiv1 "open-cluster-management.io/api/cluster/v1"
+	clusterapiv1beta1 "open-cluster-management.io/api/cluster/v1beta1"
+	clusterapiv1beta2 "open-cluster-management.io/api/cluster/v1beta2"
+	testinghelpers "open-cluster-management.io/placement/pkg/helpers/testing"
+	"open-cluster-management.io/placement/pkg/plugins"
+)
+
+func TestWhatIf(t *testing.T) {
+	placementNamespace := "test"
+	placementName := "test"
+	prod := "prod"
+
+	initObjs := []runtime.Object{
+		testinghelpers.NewClusterSet("prod").WithClusterSelector(clusterapiv1beta2.ManagedClusterSelector{
+			SelectorType: clusterapiv1beta2.LabelSelector,
+			LabelSelector: &metav1.LabelSelector{
+				MatchLabels: map[string]string{"env": "prod"},
+			},
+		}).Build(),
+		testinghelpers.NewClusterSetBinding(placementNamespace, "prod"),
+		testinghelpers.NewManagedCluster("cluster1").WithLabel("env", "prod").Build(),
+		testinghelpers.NewManagedCluster("cluster2").WithLabel("env", "prod").Build(),
+		testinghelpers.NewManagedCluster("cluster3").WithLabel("env", "dev").Build(),
+	}
+	existing := []runtime.Object{
+		testinghelpers.NewPlacement(placementNamespace, placementName).WithNOC(1).Build(),
+		testinghelpers.NewPlacementDecision(placementNamespace, "test-decision-1").
+			WithLabel(clusterapiv1beta1.PlacementLabel, placementName).WithDecisions("cluster1").Build(),
+	}
+
+	cases := []struct {
+		name             string
+		initObjs         []runtime.Object
+		request          WhatIfRequest
+		decisions        []clusterapiv1beta1.ClusterDecision
+		expectedClusters []string
+		expectedTaints   map[string]int
+		expectedNOC      *int32
+		expectedDiff     *DecisionDiff
+		expectedError    bool
+	}{
+		{
+			name:     "drain a cluster of an existing placement",
+			initObjs: append(append([]runtime.Object{}, initObjs...), existing...),
+			request: WhatIfRequest{
+				Overrides: ClusterOverrides{
+					Taints: map[string][]clusterapiv1.Taint{
+						"cluster1": {{Key: "drain", Effect: clusterapiv1.TaintEffectNoSelect}},
+					},
+				},
+			},
+			decisions:        []clusterapiv1beta1.ClusterDecision{{ClusterName: "cluster2"}},
+			expectedClusters: []string{"cluster1", "cluster2"},
+			expectedTaints:   map[string]int{"cluster1": 1, "cluster2": 0},
+			expectedNOC:      func() *int32 { n := int32(1); return &n }(),
+			expectedDiff:     &DecisionDiff{Added: []string{"cluster2"}, Removed: []string{"cluster1"}, Unchanged: []string{}},
+		},
+		{
+			name:     "remove a cluster and change labels with a new placement spec",
+			initObjs: append(append([]runtime.Object{}, initObjs...), existing...),
+			request: WhatIfRequest{
+				Placement: testinghelpers.NewPlacement("", "").WithNOC(2).Build(),
+				Overrides: ClusterOverrides{
+					RemovedClusters: []string{"cluster2"},
+					Labels: map[string]map[string]*string{
+						"cluster1": {"env": nil},
+						"cluster3": {"env": &prod},
+					},
+				},
+			},
+			decisions:        []clusterapiv1beta1.ClusterDecision{{ClusterName: "cluster1"}},
+			expectedClusters: []string{"cluster3"},
+			expectedTaints:   map[string]int{"cluster3": 0},
+			expectedNOC:      func() *int32 { n := int32(2); return &n }(),
+			expectedDiff:     &DecisionDiff{Added: []string{}, Removed: []string{}, Unchanged: []string{"cluster1"}},
+		},
+		{
+			name:             "a placement not in the hub",
+			initObjs:         initObjs,
+			request:          WhatIfRequest{Placement: testinghelpers.NewPlacement("", "").Build()},
+			expectedClusters: []string{"cluster1", "cluster2"},
+			expectedTaints:   map[string]int{"cluster1": 0, "cluster2": 0},
+		},
+		{
+			name:          "a placement with a negative number of clusters",
+			initObjs:      initObjs,
+			request:       WhatIfRequest{Placement: testinghelpers.NewPlacement("", "").WithNOC(-1).Build()},
+			expectedError: true,
+		},
+		{
+			name:     "a placement with an invalid predicate",
+			initObjs: initObjs,
+			request: WhatIfRequest{Placement: testinghelpers.NewPlacement("", "").AddPredicate(&metav1.LabelSelector{
+				MatchExpressions: []metav1.LabelSelectorRequirement{{Key: "env", Operator: "Like"}},
+			}, nil).Build()},
+			expectedError: true,
+		},
+		{
+			name:          "a placement neither in the hub nor in the request",
+			initObjs:      initObjs,
+			expectedError: true,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			clusterClient := clusterfake.NewSimpleClientset(c.initObjs...)
+			clusterInformerFactory := testinghelpers.NewClusterInformerFactory(clusterClient, c.initObjs...)
+			s := &testScheduler{result: &testResult{decisions: c.decisions}}
+			debugger := NewDebugger(
+				s,
+				clusterInformerFactory.Cluster().V1beta1().Placements(),
+				clusterInformerFactory.Cluster().V1().ManagedClusters(),
+				clusterInformerFactory.Cluster().V1beta2().ManagedClusterSets(),
+				clusterInformerFactory.Cluster().V1beta2().ManagedClusterSetBindings(),
+				clusterInformerFactory.Cluster().V1beta1().PlacementDecisions(),
+			)
+			server := httptest.NewServer(http.HandlerFunc(debugger.Handler))
+			defer server.Close()
+
+			body, _ := json.Marshal(c.request)
+			res, err := http.Post(fmt.Sprintf("%s%s%s/%s", server.URL, DebugPath, placementNamespace, placementName),
+				"application/json", bytes.NewReader(body))
+			if err != nil {
+				t.Fatalf("unexpected err: %v", err)
+			}
+			responseBody, err := ioutil.ReadAll(res.Body)
+			if err != nil {
+				t.Fatalf("unexpected err: %v", err)
+			}
+			result := &WhatIfResult{}
+			if err := json.Unmarshal(responseBody, result); err != nil {
+				t.Fatalf("unexpected err: %v", err)
+			}
+
+			if c.expectedError {
+				if len(result.Error) == 0 {
+					t.Errorf("expected error, but got %s", string(responseBody))
+				}
+				if s.placement != nil {
+					t.Errorf("expected the placement not scheduled, but got %s/%s", s.placement.Namespace, s.placement.Name)
+				}
+				return
+			}
+
+			if !reflect.DeepEqual(s.clusters, c.expectedClusters) {
+				t.Errorf("expected clusters %v, but got %v", c.expectedClusters, s.clusters)
+			}
+			taints := map[string]int{}
+			for name, cluster := range s.scheduled {
+				taints[name] = len(cluster.Spec.Taints)
+			}
+			if !reflect.DeepEqual(taints, c.expectedTaints) {
+				t.Errorf("expected taints %v, but got %v", c.expectedTaints, taints)
+			}
+			// the plugins get the clusters with the overrides as well
+			handle := testinghelpers.NewFakePluginHandle(t, clusterfake.NewSimpleClientset())
+			for name := range s.scheduled {
+				cluster, err := plugins.ClusterLister(s.ctx, handle).Get(name)
+				if err != nil {
+					t.Fatalf("unexpected err: %v", err)
+				}
+				if len(cluster.Spec.Taints) != c.expectedTaints[name] {
+					t.Errorf("expected %d taints of cluster %s for the plugins, but got %d", c.expectedTaints[name], name, len(cluster.Spec.Taints))
+				}
+			}
+			if s.placement.Namespace != placementNamespace || s.placement.Name != placementName {
+				t.Errorf("expected placement %s/%s, but got %s/%s", placementNamespace, placementName, s.placement.Namespace, s.placement.Name)
+			}
+			if c.expectedNOC != nil && !reflect.DeepEqual(s.placement.Spec.NumberOfClusters, c.expectedNOC) {
+				t.Errorf("expected numberOfClusters %v, but got %v", *c.expectedNOC, s.placement.Spec.NumberOfClusters)
+			}
+			if !reflect.DeepEqual(result.Diff, c.expectedDiff) {
+				t.Errorf("expected diff %v, but got %v", c.expectedDiff, result.Diff)
+			}
+		})
+	}
+}
+
+func TestMethodNotAllowed(t *testing.T) {
+	debugger := &Debugger{}
+	server := httptest.NewServer(http.HandlerFunc(debugger.Handler))
+	defer server.Close()
+
+	req, _ := http.NewRequest(http.MethodDelete, server.URL+DebugPath+"test/test", nil)
+	res, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+	if res.StatusCode != http.StatusMethodNotAllowed {
+		t.Errorf("expected status %d, but got %d", http.StatusMethodNotAllowed, res.StatusCode)
+	}
+}
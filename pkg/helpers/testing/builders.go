@@ -96,6 +96,17 @@ func (b *placementBuilder) WithClusterSets(clusterSets ...string) *placementBuil
 	return b
 }
 
+func (b *placementBuilder) WithSpreadConstraint(topologyKey string, topologyKeyType clusterapiv1beta1.TopologyKeyType,
+	maxSkew int32, whenUnsatisfiable clusterapiv1beta1.UnsatisfiableMaxSkewAction) *placementBuilder {
+	b.placement.Spec.SpreadPolicy.SpreadConstraints = append(b.placement.Spec.SpreadPolicy.SpreadConstraints, clusterapiv1beta1.SpreadConstraintsTerm{
+		TopologyKey:       topologyKey,
+		TopologyKeyType:   topologyKeyType,
+		MaxSkew:           maxSkew,
+		WhenUnsatisfiable: whenUnsatisfiable,
+	})
+	return b
+}
+
 func (b *placementBuilder) WithDeletionTimestamp() *placementBuilder {
 	now := metav1.Now()
 	b.placement.DeletionTimestamp = &now
@@ -287,6 +298,20 @@ func (b *managedClusterBuilder) WithTaint(taint *clusterapiv1.Taint) *managedClu
 	return b
 }
 
+func (b *managedClusterBuilder) WithHubAcceptsClient(accepted bool) *managedClusterBuilder {
+	b.cluster.Spec.HubAcceptsClient = accepted
+	return b
+}
+
+func (b *managedClusterBuilder) WithCondition(conditionType string, status metav1.ConditionStatus, lastTransitionTime time.Time) *managedClusterBuilder {
+	b.cluster.Status.Conditions = append(b.cluster.Status.Conditions, metav1.Condition{
+		Type:               conditionType,
+		Status:             status,
+		LastTransitionTime: metav1.NewTime(lastTransitionTime),
+	})
+	return b
+}
+
 func (b *managedClusterBuilder) Build() *clusterapiv1.ManagedCluster {
 	return b.cluster
 }
@@ -1,13 +1,17 @@
 package testing
 
 import (
+	"context"
 	"testing"
 
 	"github.com/openshift/library-go/pkg/operator/events"
 	"github.com/openshift/library-go/pkg/operator/events/eventstesting"
+	coordinationv1 "k8s.io/api/coordination/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	coordinationlisterv1 "k8s.io/client-go/listers/coordination/v1"
 	clienttesting "k8s.io/client-go/testing"
+	"k8s.io/client-go/tools/cache"
 	kevents "k8s.io/client-go/tools/events"
 	"k8s.io/client-go/util/workqueue"
 	clusterclient "open-cluster-management.io/api/client/cluster/clientset/versioned"
@@ -15,6 +19,8 @@ import (
 	clusterlisterv1 "open-cluster-management.io/api/client/cluster/listers/cluster/v1"
 	clusterlisterv1alpha1 "open-cluster-management.io/api/client/cluster/listers/cluster/v1alpha1"
 	clusterlisterv1beta1 "open-cluster-management.io/api/client/cluster/listers/cluster/v1beta1"
+	clusterapiv1beta1 "open-cluster-management.io/api/cluster/v1beta1"
+	schedulingcache "open-cluster-management.io/placement/pkg/controllers/scheduling/cache"
 )
 
 type FakeSyncContext struct {
@@ -40,7 +46,9 @@ type FakePluginHandle struct {
 	placementDecisionLister clusterlisterv1beta1.PlacementDecisionLister
 	scoreLister             clusterlisterv1alpha1.AddOnPlacementScoreLister
 	clusterLister           clusterlisterv1.ManagedClusterLister
+	leaseLister             coordinationlisterv1.LeaseLister
 	client                  clusterclient.Interface
+	decisionCache           *schedulingcache.Cache
 }
 
 func (f *FakePluginHandle) EventRecorder() kevents.EventRecorder { return f.recorder }
@@ -53,19 +61,49 @@ func (f *FakePluginHandle) ScoreLister() clusterlisterv1alpha1.AddOnPlacementSco
 func (f *FakePluginHandle) ClusterLister() clusterlisterv1.ManagedClusterLister {
 	return f.clusterLister
 }
+func (f *FakePluginHandle) LeaseLister() coordinationlisterv1.LeaseLister {
+	return f.leaseLister
+}
 func (f *FakePluginHandle) ClusterClient() clusterclient.Interface {
 	return f.client
 }
+func (f *FakePluginHandle) Snapshot(ctx context.Context) *schedulingcache.Snapshot {
+	if snapshot := schedulingcache.SnapshotFromContext(ctx); snapshot != nil {
+		return snapshot
+	}
+	return f.decisionCache.Snapshot()
+}
+func (f *FakePluginHandle) DecisionCache() *schedulingcache.Cache {
+	return f.decisionCache
+}
 
 func NewFakePluginHandle(
-	t *testing.T, client *clusterfake.Clientset, objects ...runtime.Object) *FakePluginHandle {
+	t testing.TB, client *clusterfake.Clientset, objects ...runtime.Object) *FakePluginHandle {
 	informers := NewClusterInformerFactory(client, objects...)
+
+	// the decision cache is built from the placementdecisions in the objects, and the leases
+	// are indexed separately since they are not cluster objects
+	decisionCache := schedulingcache.NewCache()
+	leaseIndexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+	for _, obj := range objects {
+		switch obj := obj.(type) {
+		case *clusterapiv1beta1.PlacementDecision:
+			decisionCache.OnAdd(obj)
+		case *coordinationv1.Lease:
+			if err := leaseIndexer.Add(obj); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+
 	return &FakePluginHandle{
+		decisionCache:           decisionCache,
 		recorder:                kevents.NewFakeRecorder(100),
 		client:                  client,
 		placementDecisionLister: informers.Cluster().V1beta1().PlacementDecisions().Lister(),
 		scoreLister:             informers.Cluster().V1alpha1().AddOnPlacementScores().Lister(),
 		clusterLister:           informers.Cluster().V1().ManagedClusters().Lister(),
+		leaseLister:             coordinationlisterv1.NewLeaseLister(leaseIndexer),
 	}
 }
 
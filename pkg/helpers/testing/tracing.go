@@ -0,0 +1,52 @@
+package testing
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"go.opentelemetry.io/otel"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// SpanRecorder records the ended spans.
+type SpanRecorder struct {
+	lock  sync.Mutex
+	spans []sdktrace.ReadOnlySpan
+}
+
+var _ sdktrace.SpanProcessor = &SpanRecorder{}
+
+// NewSpanRecorder sets a tracer provider recording all the spans as the global tracer provider,
+// and replaces it with a noop tracer provider when the test finishes.
+func NewSpanRecorder(t *testing.T) *SpanRecorder {
+	recorder := &SpanRecorder{}
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(
+		sdktrace.WithSampler(sdktrace.AlwaysSample()),
+		sdktrace.WithSpanProcessor(recorder),
+	))
+	t.Cleanup(func() {
+		otel.SetTracerProvider(trace.NewNoopTracerProvider())
+	})
+	return recorder
+}
+
+func (r *SpanRecorder) OnStart(parent context.Context, s sdktrace.ReadWriteSpan) {}
+
+func (r *SpanRecorder) OnEnd(s sdktrace.ReadOnlySpan) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.spans = append(r.spans, s)
+}
+
+func (r *SpanRecorder) Shutdown(ctx context.Context) error { return nil }
+
+func (r *SpanRecorder) ForceFlush(ctx context.Context) error { return nil }
+
+// Spans returns the ended spans in the order they end.
+func (r *SpanRecorder) Spans() []sdktrace.ReadOnlySpan {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	return append([]sdktrace.ReadOnlySpan{}, r.spans...)
+}
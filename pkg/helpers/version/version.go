@@ -0,0 +1,73 @@
+package version
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/blang/semver/v4"
+)
+
+// ParseVersion parses a version like v1.27.3, 1.27 or 4.12.0-rc.1 tolerantly. The pre-release
+// and build metadata are dropped, since distributions put vendor suffixes there, like
+// v1.27.3-eks-4f4795d or v1.27.3+k3s1, which should not make the version compare lower.
+func ParseVersion(s string) (semver.Version, error) {
+	v, err := semver.ParseTolerant(strings.TrimSpace(s))
+	if err != nil {
+		return semver.Version{}, err
+	}
+	v.Pre = nil
+	v.Build = nil
+	return v, nil
+}
+
+// ParseRange parses a version range like ">=1.26 <1.29 || >=1.30". Compared to semver.ParseRange,
+// the versions may have a "v" prefix and omit the minor or patch version, which are zero.
+func ParseRange(s string) (semver.Range, error) {
+	if len(strings.TrimSpace(s)) == 0 {
+		return nil, fmt.Errorf("empty version range")
+	}
+
+	parts := []string{}
+	operator := ""
+	for _, field := range strings.Fields(s) {
+		if field == "||" {
+			if len(operator) > 0 {
+				return nil, fmt.Errorf("no version after %q in range %q", operator, s)
+			}
+			parts = append(parts, field)
+			continue
+		}
+
+		// the operator may be separated from the version by spaces
+		i := strings.IndexFunc(field, func(r rune) bool { return !strings.ContainsRune("<>=!", r) })
+		if i < 0 {
+			operator += field
+			continue
+		}
+		operator += field[:i]
+		parts = append(parts, operator+normalizeVersion(field[i:]))
+		operator = ""
+	}
+	if len(operator) > 0 {
+		return nil, fmt.Errorf("no version after %q in range %q", operator, s)
+	}
+
+	return semver.ParseRange(strings.Join(parts, " "))
+}
+
+// normalizeVersion trims the "v" prefix and appends the omitted minor and patch versions.
+func normalizeVersion(v string) string {
+	v = strings.TrimPrefix(v, "v")
+	core := v
+	if i := strings.IndexAny(v, "-+"); i >= 0 {
+		core = v[:i]
+	}
+	if strings.Contains(core, "x") {
+		return v
+	}
+	for n := strings.Count(core, "."); n < 2; n++ {
+		v = strings.Replace(v, core, core+".0", 1)
+		core += ".0"
+	}
+	return v
+}
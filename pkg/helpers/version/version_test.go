@@ -0,0 +1,124 @@
+package version
+
+import (
+	"testing"
+
+	"github.com/blang/semver/v4"
+)
+
+func mustParse(t *testing.T, v string) semver.Version {
+	version, err := semver.Parse(v)
+	if err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+	return version
+}
+
+func TestParseVersion(t *testing.T) {
+	cases := []struct {
+		version     string
+		expected    string
+		expectedErr bool
+	}{
+		{version: "v1.27.3", expected: "1.27.3"},
+		{version: "1.27", expected: "1.27.0"},
+		{version: "v1.27.3-eks-4f4795d", expected: "1.27.3"},
+		{version: "v1.27.3+k3s1", expected: "1.27.3"},
+		{version: "4.12.0-rc.1", expected: "4.12.0"},
+		{version: "", expectedErr: true},
+		{version: "latest", expectedErr: true},
+	}
+
+	for _, c := range cases {
+		t.Run(c.version, func(t *testing.T) {
+			v, err := ParseVersion(c.version)
+			if c.expectedErr {
+				if err == nil {
+					t.Errorf("expected error, but got %v", v)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected err: %v", err)
+			}
+			if v.String() != c.expected {
+				t.Errorf("expected %s, but got %s", c.expected, v.String())
+			}
+		})
+	}
+}
+
+func TestParseRange(t *testing.T) {
+	cases := []struct {
+		name         string
+		versionRange string
+		matched      []string
+		unmatched    []string
+		expectedErr  bool
+	}{
+		{
+			name:         "range with partial versions",
+			versionRange: ">=1.26 <1.29",
+			matched:      []string{"1.26.0", "1.28.9"},
+			unmatched:    []string{"1.25.9", "1.29.0"},
+		},
+		{
+			name:         "operators separated by spaces",
+			versionRange: ">= v1.26.1 < 1.27",
+			matched:      []string{"1.26.1"},
+			unmatched:    []string{"1.26.0", "1.27.0"},
+		},
+		{
+			name:         "or",
+			versionRange: "<1.20 || >=1.28",
+			matched:      []string{"1.19.3", "1.28.0"},
+			unmatched:    []string{"1.20.0", "1.27.9"},
+		},
+		{
+			name:         "wildcard",
+			versionRange: "1.27.x",
+			matched:      []string{"1.27.0", "1.27.9"},
+			unmatched:    []string{"1.28.0"},
+		},
+		{
+			name:         "empty",
+			versionRange: " ",
+			expectedErr:  true,
+		},
+		{
+			name:         "no version",
+			versionRange: ">=1.26 <",
+			expectedErr:  true,
+		},
+		{
+			name:         "invalid version",
+			versionRange: ">=latest",
+			expectedErr:  true,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			r, err := ParseRange(c.versionRange)
+			if c.expectedErr {
+				if err == nil {
+					t.Errorf("expected error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected err: %v", err)
+			}
+			for _, v := range c.matched {
+				if !r(mustParse(t, v)) {
+					t.Errorf("expected %s to match %q", v, c.versionRange)
+				}
+			}
+			for _, v := range c.unmatched {
+				if r(mustParse(t, v)) {
+					t.Errorf("expected %s not to match %q", v, c.versionRange)
+				}
+			}
+		})
+	}
+}
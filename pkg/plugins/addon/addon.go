@@ -9,6 +9,7 @@ import (
 	clusterapiv1 "open-cluster-management.io/api/cluster/v1"
 	clusterapiv1beta1 "open-cluster-management.io/api/cluster/v1beta1"
 	"open-cluster-management.io/placement/pkg/controllers/framework"
+	"open-cluster-management.io/placement/pkg/controllers/metrics"
 	"open-cluster-management.io/placement/pkg/plugins"
 )
 
@@ -22,6 +23,8 @@ const (
 )
 
 var _ plugins.Prioritizer = &AddOn{}
+var _ plugins.ClusterIndependent = &AddOn{}
+var _ plugins.ClusterFieldsReader = &AddOn{}
 var AddOnClock = (clock.Clock)(clock.RealClock{})
 
 type AddOn struct {
@@ -66,9 +69,20 @@ func (c *AddOn) Description() string {
 	return description
 }
 
+// ClusterFields returns no field since the clusters are scored by the AddOnPlacementScores.
+func (c *AddOn) ClusterFields() []plugins.ClusterField {
+	return nil
+}
+
+// ClusterIndependent returns true since each cluster is scored separately.
+func (c *AddOn) ClusterIndependent() bool {
+	return true
+}
+
 func (c *AddOn) Score(ctx context.Context, placement *clusterapiv1beta1.Placement, clusters []*clusterapiv1.ManagedCluster) (plugins.PluginScoreResult, *framework.Status) {
 	scores := map[string]int64{}
 	expiredScores := ""
+	numOfExpired := 0
 	status := framework.NewStatus(c.Name(), framework.Success, "")
 
 	for _, cluster := range clusters {
@@ -86,6 +100,7 @@ func (c *AddOn) Score(ctx context.Context, placement *clusterapiv1beta1.Placemen
 		// check score valid time
 		if (addOnScores.Status.ValidUntil != nil) && AddOnClock.Now().After(addOnScores.Status.ValidUntil.Time) {
 			expiredScores = fmt.Sprintf("%s %s/%s", expiredScores, namespace, c.resourceName)
+			numOfExpired++
 			continue
 		}
 
@@ -98,6 +113,7 @@ func (c *AddOn) Score(ctx context.Context, placement *clusterapiv1beta1.Placemen
 	}
 
 	if len(expiredScores) > 0 {
+		metrics.RecordExpiredAddOnScores(numOfExpired)
 		status = framework.NewStatus(
 			c.Name(),
 			framework.Warning,
@@ -4,7 +4,6 @@ import (
 	"context"
 	"reflect"
 
-	"k8s.io/apimachinery/pkg/labels"
 	clusterapiv1 "open-cluster-management.io/api/cluster/v1"
 	clusterapiv1beta1 "open-cluster-management.io/api/cluster/v1beta1"
 	"open-cluster-management.io/placement/pkg/controllers/framework"
@@ -21,6 +20,7 @@ const (
 )
 
 var _ plugins.Prioritizer = &Balance{}
+var _ plugins.ClusterFieldsReader = &Balance{}
 
 type Balance struct {
 	handle plugins.Handle
@@ -40,33 +40,31 @@ func (b *Balance) Description() string {
 	return description
 }
 
+// ClusterFields returns no field since the clusters are scored by the decisions.
+func (b *Balance) ClusterFields() []plugins.ClusterField {
+	return nil
+}
+
 func (b *Balance) Score(ctx context.Context, placement *clusterapiv1beta1.Placement, clusters []*clusterapiv1.ManagedCluster) (plugins.PluginScoreResult, *framework.Status) {
 	scores := map[string]int64{}
 	for _, cluster := range clusters {
 		scores[cluster.Name] = plugins.MaxClusterScore
 	}
 
-	decisions, err := b.handle.DecisionLister().List(labels.Everything())
-	if err != nil {
-		return plugins.PluginScoreResult{}, framework.NewStatus(
-			b.Name(),
-			framework.Error,
-			err.Error(),
-		)
+	snapshot := b.handle.Snapshot(ctx)
+	decisionCount := snapshot.DecisionCounts()
+	// Do not count the decisions of the placement that is being scheduled.
+	for clusterName := range snapshot.PlacementClusters(placement.Namespace, placement.Name) {
+		decisionCount[clusterName]--
+		if decisionCount[clusterName] <= 0 {
+			delete(decisionCount, clusterName)
+		}
 	}
 
-	var maxCount int64
-	decisionCount := map[string]int64{}
-	for _, decision := range decisions {
-		// Do not count the decision that is being scheduled.
-		if decision.Labels[placementLabel] == placement.Name && decision.Namespace == placement.Namespace {
-			continue
-		}
-		for _, d := range decision.Status.Decisions {
-			decisionCount[d.ClusterName] = decisionCount[d.ClusterName] + 1
-			if decisionCount[d.ClusterName] > maxCount {
-				maxCount = decisionCount[d.ClusterName]
-			}
+	var maxCount int
+	for _, count := range decisionCount {
+		if count > maxCount {
+			maxCount = count
 		}
 	}
 
@@ -0,0 +1,267 @@
+package celpredicate
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+
+	"github.com/google/cel-go/cel"
+	"github.com/google/cel-go/ext"
+	"k8s.io/utils/lru"
+	clusterapiv1 "open-cluster-management.io/api/cluster/v1"
+	clusterapiv1beta1 "open-cluster-management.io/api/cluster/v1beta1"
+	"open-cluster-management.io/placement/pkg/controllers/framework"
+	"open-cluster-management.io/placement/pkg/plugins"
+)
+
+var _ plugins.Filter = &CELPredicate{}
+var _ plugins.ClusterIndependent = &CELPredicate{}
+var _ plugins.ClusterFieldsReader = &CELPredicate{}
+
+const (
+	// CELPredicatesAnnotation is the annotation of a placement to select the clusters with CEL
+	// expressions. The value is a JSON list of expressions, for example
+	//
+	//	["cluster.claims[\"region\"].startsWith(\"eu-\") && cluster.allocatable.cpu > 100"]
+	CELPredicatesAnnotation = "cluster.open-cluster-management.io/cel-predicates"
+
+	// DefaultCostLimit is the default runtime cost limit of evaluating an expression on a cluster.
+	DefaultCostLimit uint64 = 1000000
+
+	// programCacheSize is the number of placements whose compiled programs are cached.
+	programCacheSize = 1024
+
+	description = `
+	CELPredicate filter selects the clusters on which all the CEL expressions in the annotation
+	cluster.open-cluster-management.io/cel-predicates of the placement evaluate to true. The
+	expressions refer to the cluster with the variable cluster, which has the fields name,
+	labels, claims, allocatable, capacity, version, taints and conditions. All the clusters are
+	selected if the placement does not have the annotation.
+	`
+)
+
+// Args are the arguments of the CELPredicate filter in the scheduler configuration.
+type Args struct {
+	// CostLimit is the runtime cost limit of evaluating an expression on a cluster. A cluster is
+	// not selected if the cost limit is exceeded. The default is 1000000.
+	// +optional
+	CostLimit *uint64 `json:"costLimit,omitempty"`
+}
+
+type CELPredicate struct {
+	env       *cel.Env
+	costLimit uint64
+	// programs caches the compiled programs of placements by namespace/name.
+	programs *lru.Cache
+}
+
+// compiledPredicates are the programs compiled from the annotation of a placement generation.
+type compiledPredicates struct {
+	generation int64
+	source     string
+	programs   []cel.Program
+	err        error
+}
+
+func New(handle plugins.Handle, args *Args) (*CELPredicate, error) {
+	costLimit := DefaultCostLimit
+	if args != nil && args.CostLimit != nil {
+		costLimit = *args.CostLimit
+	}
+	if costLimit == 0 {
+		return nil, fmt.Errorf("costLimit must be positive")
+	}
+
+	env, err := cel.NewEnv(
+		cel.Variable("cluster", cel.MapType(cel.StringType, cel.DynType)),
+		cel.CrossTypeNumericComparisons(true),
+		ext.Strings(),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &CELPredicate{
+		env:       env,
+		costLimit: costLimit,
+		programs:  lru.New(programCacheSize),
+	}, nil
+}
+
+func (c *CELPredicate) Name() string {
+	return reflect.TypeOf(*c).Name()
+}
+
+func (c *CELPredicate) Description() string {
+	return description
+}
+
+// ClusterFields returns all the fields exposed to the expressions.
+func (c *CELPredicate) ClusterFields() []plugins.ClusterField {
+	return []plugins.ClusterField{
+		plugins.ClusterFieldLabels,
+		plugins.ClusterFieldClaims,
+		plugins.ClusterFieldTaints,
+		plugins.ClusterFieldResources,
+		plugins.ClusterFieldVersion,
+		plugins.ClusterFieldConditions,
+	}
+}
+
+// ClusterIndependent returns true since each cluster is filtered separately.
+func (c *CELPredicate) ClusterIndependent() bool {
+	return true
+}
+
+func (c *CELPredicate) Filter(ctx context.Context, placement *clusterapiv1beta1.Placement, clusters []*clusterapiv1.ManagedCluster) (plugins.PluginFilterResult, *framework.Status) {
+	status := framework.NewStatus(c.Name(), framework.Success, "")
+
+	source, ok := placement.GetAnnotations()[CELPredicatesAnnotation]
+	if !ok {
+		return plugins.PluginFilterResult{
+			Filtered: clusters,
+		}, status
+	}
+
+	programs, err := c.compile(placement, source)
+	if err != nil {
+		return plugins.PluginFilterResult{}, framework.NewStatus(
+			c.Name(),
+			framework.Misconfigured,
+			fmt.Sprintf("invalid annotation %s: %v", CELPredicatesAnnotation, err),
+		)
+	}
+
+	matched := []*clusterapiv1.ManagedCluster{}
+	rejected := map[string]string{}
+	for _, cluster := range clusters {
+		if reason := evaluate(programs, cluster); len(reason) > 0 {
+			rejected[cluster.Name] = reason
+			continue
+		}
+		matched = append(matched, cluster)
+	}
+
+	return plugins.PluginFilterResult{
+		Filtered: matched,
+		Rejected: rejected,
+	}, status
+}
+
+func (c *CELPredicate) RequeueAfter(ctx context.Context, placement *clusterapiv1beta1.Placement) (plugins.PluginRequeueResult, *framework.Status) {
+	return plugins.PluginRequeueResult{}, framework.NewStatus(c.Name(), framework.Success, "")
+}
+
+// compile returns the programs of the expressions in the annotation. The programs are compiled
+// once for each generation of the placement. Since the annotations of a placement can be changed
+// without a new generation, the cached programs are compiled again if the annotation is changed.
+func (c *CELPredicate) compile(placement *clusterapiv1beta1.Placement, source string) ([]cel.Program, error) {
+	key := placement.Namespace + "/" + placement.Name
+	if cached, ok := c.programs.Get(key); ok {
+		compiled := cached.(*compiledPredicates)
+		if compiled.generation == placement.Generation && compiled.source == source {
+			return compiled.programs, compiled.err
+		}
+	}
+
+	programs, err := c.compileExpressions(source)
+	c.programs.Add(key, &compiledPredicates{
+		generation: placement.Generation,
+		source:     source,
+		programs:   programs,
+		err:        err,
+	})
+	return programs, err
+}
+
+func (c *CELPredicate) compileExpressions(source string) ([]cel.Program, error) {
+	expressions := []string{}
+	if err := json.Unmarshal([]byte(source), &expressions); err != nil {
+		return nil, err
+	}
+
+	programs := []cel.Program{}
+	for i, expression := range expressions {
+		ast, issues := c.env.Compile(expression)
+		if issues != nil && issues.Err() != nil {
+			return nil, fmt.Errorf("[%d]: %v", i, issues.Err())
+		}
+		if outputType := ast.OutputType(); !outputType.IsAssignableType(cel.BoolType) {
+			return nil, fmt.Errorf("[%d]: expression must evaluate to bool, but got %v", i, outputType)
+		}
+
+		program, err := c.env.Program(ast, cel.CostLimit(c.costLimit))
+		if err != nil {
+			return nil, fmt.Errorf("[%d]: %v", i, err)
+		}
+		programs = append(programs, program)
+	}
+	return programs, nil
+}
+
+// evaluate returns the reason why the cluster does not match all the programs, or an empty
+// string if it matches.
+func evaluate(programs []cel.Program, cluster *clusterapiv1.ManagedCluster) string {
+	activation := map[string]interface{}{
+		"cluster": clusterObject(cluster),
+	}
+	for i, program := range programs {
+		value, _, err := program.Eval(activation)
+		if err != nil {
+			return fmt.Sprintf("expression [%d] failed: %v", i, err)
+		}
+		if matched, ok := value.Value().(bool); !ok || !matched {
+			return fmt.Sprintf("expression [%d] is not true", i)
+		}
+	}
+	return ""
+}
+
+// clusterObject converts the cluster to the object exposed to the expressions.
+func clusterObject(cluster *clusterapiv1.ManagedCluster) map[string]interface{} {
+	labels := map[string]interface{}{}
+	for k, v := range cluster.Labels {
+		labels[k] = v
+	}
+
+	claims := map[string]interface{}{}
+	for _, claim := range cluster.Status.ClusterClaims {
+		claims[claim.Name] = claim.Value
+	}
+
+	taints := []interface{}{}
+	for _, taint := range cluster.Spec.Taints {
+		taints = append(taints, map[string]interface{}{
+			"key":    taint.Key,
+			"value":  taint.Value,
+			"effect": string(taint.Effect),
+		})
+	}
+
+	conditions := map[string]interface{}{}
+	for _, condition := range cluster.Status.Conditions {
+		conditions[condition.Type] = string(condition.Status)
+	}
+
+	return map[string]interface{}{
+		"name":        cluster.Name,
+		"labels":      labels,
+		"claims":      claims,
+		"allocatable": resourceList(cluster.Status.Allocatable),
+		"capacity":    resourceList(cluster.Status.Capacity),
+		"version":     cluster.Status.Version.Kubernetes,
+		"taints":      taints,
+		"conditions":  conditions,
+	}
+}
+
+// resourceList converts the quantities of the resources to numbers, for example the cpu in
+// cores and the memory in bytes.
+func resourceList(resources clusterapiv1.ResourceList) map[string]interface{} {
+	result := map[string]interface{}{}
+	for name, quantity := range resources {
+		result[string(name)] = quantity.AsApproximateFloat64()
+	}
+	return result
+}
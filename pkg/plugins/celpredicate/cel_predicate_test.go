@@ -0,0 +1,200 @@
+package celpredicate
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	clusterapiv1 "open-cluster-management.io/api/cluster/v1"
+	"open-cluster-management.io/placement/pkg/controllers/framework"
+	testinghelpers "open-cluster-management.io/placement/pkg/helpers/testing"
+)
+
+func newClusters() []*clusterapiv1.ManagedCluster {
+	eu := testinghelpers.NewManagedCluster("eu-large").WithLabel("env", "prod").WithClaim("region", "eu-west-1").
+		WithResource(clusterapiv1.ResourceCPU, "120", "128").WithResource(clusterapiv1.ResourceMemory, "500Gi", "512Gi").
+		WithCondition(clusterapiv1.ManagedClusterConditionAvailable, metav1.ConditionTrue, time.Time{}).Build()
+	eu.Status.Version.Kubernetes = "v1.28.3"
+
+	small := testinghelpers.NewManagedCluster("eu-small").WithLabel("env", "dev").WithClaim("region", "eu-central-1").
+		WithResource(clusterapiv1.ResourceCPU, "7500m", "8").
+		WithTaint(&clusterapiv1.Taint{Key: "gpu", Effect: clusterapiv1.TaintEffectPreferNoSelect}).Build()
+
+	us := testinghelpers.NewManagedCluster("us-large").WithClaim("region", "us-east-1").
+		WithResource(clusterapiv1.ResourceCPU, "200", "256").Build()
+
+	return []*clusterapiv1.ManagedCluster{eu, small, us}
+}
+
+func TestFilter(t *testing.T) {
+	cases := []struct {
+		name             string
+		annotations      map[string]string
+		expectedCode     framework.Code
+		expectedClusters []string
+		expectedRejected map[string]string
+	}{
+		{
+			name:             "no annotation",
+			expectedClusters: []string{"eu-large", "eu-small", "us-large"},
+		},
+		{
+			name: "claims and allocatable",
+			annotations: map[string]string{
+				CELPredicatesAnnotation: `["cluster.claims[\"region\"].startsWith(\"eu-\") && cluster.allocatable.cpu > 100"]`,
+			},
+			expectedClusters: []string{"eu-large"},
+			expectedRejected: map[string]string{
+				"eu-small": "expression [0] is not true",
+				"us-large": "expression [0] is not true",
+			},
+		},
+		{
+			name: "all expressions must be true",
+			annotations: map[string]string{
+				CELPredicatesAnnotation: `["cluster.allocatable.cpu > 7", "cluster.capacity.cpu < 200"]`,
+			},
+			expectedClusters: []string{"eu-large", "eu-small"},
+			expectedRejected: map[string]string{
+				"us-large": "expression [1] is not true",
+			},
+		},
+		{
+			name: "taints, conditions and version",
+			annotations: map[string]string{
+				CELPredicatesAnnotation: `["!cluster.taints.exists(t, t.key == \"gpu\")",
+					"cluster.version.startsWith(\"v1.28\") || cluster.conditions[\"ManagedClusterConditionAvailable\"] == \"True\""]`,
+			},
+			expectedClusters: []string{"eu-large"},
+			expectedRejected: map[string]string{
+				"eu-small": "expression [0] is not true",
+				"us-large": "expression [1] failed: no such key: ManagedClusterConditionAvailable",
+			},
+		},
+		{
+			name: "missing label",
+			annotations: map[string]string{
+				CELPredicatesAnnotation: `["'env' in cluster.labels && cluster.labels.env == 'prod'"]`,
+			},
+			expectedClusters: []string{"eu-large"},
+			expectedRejected: map[string]string{
+				"eu-small": "expression [0] is not true",
+				"us-large": "expression [0] is not true",
+			},
+		},
+		{
+			name:         "invalid json",
+			annotations:  map[string]string{CELPredicatesAnnotation: `cluster.name == "a"`},
+			expectedCode: framework.Misconfigured,
+		},
+		{
+			name:         "syntax error",
+			annotations:  map[string]string{CELPredicatesAnnotation: `["cluster.name =="]`},
+			expectedCode: framework.Misconfigured,
+		},
+		{
+			name:         "undeclared variable",
+			annotations:  map[string]string{CELPredicatesAnnotation: `["placement.name == 'a'"]`},
+			expectedCode: framework.Misconfigured,
+		},
+		{
+			name:         "not bool",
+			annotations:  map[string]string{CELPredicatesAnnotation: `["size(cluster.labels)"]`},
+			expectedCode: framework.Misconfigured,
+		},
+	}
+
+	p, err := New(nil, nil)
+	if err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			placement := testinghelpers.NewPlacementWithAnnotations("test", c.name, c.annotations).Build()
+			result, status := p.Filter(context.TODO(), placement, newClusters())
+			if status.Code() != c.expectedCode {
+				t.Fatalf("expected code %v, but got %v: %s", c.expectedCode, status.Code(), status.Message())
+			}
+			if status.IsError() {
+				return
+			}
+
+			actual := []string{}
+			for _, cluster := range result.Filtered {
+				actual = append(actual, cluster.Name)
+			}
+			if !reflect.DeepEqual(actual, c.expectedClusters) {
+				t.Errorf("expected clusters %v, but got %v", c.expectedClusters, actual)
+			}
+			if len(c.expectedRejected) == 0 && len(result.Rejected) == 0 {
+				return
+			}
+			if !reflect.DeepEqual(result.Rejected, c.expectedRejected) {
+				t.Errorf("expected rejected clusters %v, but got %v", c.expectedRejected, result.Rejected)
+			}
+		})
+	}
+}
+
+func TestCostLimit(t *testing.T) {
+	costLimit := uint64(10)
+	p, err := New(nil, &Args{CostLimit: &costLimit})
+	if err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+
+	placement := testinghelpers.NewPlacementWithAnnotations("test", "test", map[string]string{
+		CELPredicatesAnnotation: `["[1, 2, 3, 4, 5, 6, 7, 8, 9, 10].all(i, [1, 2, 3, 4, 5, 6, 7, 8, 9, 10].all(j, i * j > 0))"]`,
+	}).Build()
+	result, status := p.Filter(context.TODO(), placement, newClusters())
+	if status.IsError() {
+		t.Fatalf("unexpected err: %v", status.AsError())
+	}
+	if len(result.Filtered) != 0 {
+		t.Errorf("expected no cluster selected when the cost limit is exceeded, but got %d", len(result.Filtered))
+	}
+	if reason := result.Rejected["eu-large"]; !strings.Contains(reason, "cost limit exceeded") {
+		t.Errorf("expected cost limit exceeded, but got %q", reason)
+	}
+
+	zero := uint64(0)
+	if _, err := New(nil, &Args{CostLimit: &zero}); err == nil {
+		t.Errorf("expected error for zero cost limit")
+	}
+}
+
+func TestProgramCache(t *testing.T) {
+	p, err := New(nil, nil)
+	if err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+
+	placement := testinghelpers.NewPlacementWithAnnotations("test", "test", map[string]string{
+		CELPredicatesAnnotation: `["cluster.name == 'us-large'"]`,
+	}).Build()
+	placement.Generation = 1
+	programs, err := p.compile(placement, placement.Annotations[CELPredicatesAnnotation])
+	if err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+
+	// the programs are reused for the same generation
+	cached, _ := p.compile(placement, placement.Annotations[CELPredicatesAnnotation])
+	if &cached[0] != &programs[0] {
+		t.Errorf("expected programs cached for the same generation")
+	}
+
+	// the programs are compiled again for a new generation or annotation
+	placement.Generation = 2
+	compiled, _ := p.compile(placement, placement.Annotations[CELPredicatesAnnotation])
+	if &compiled[0] == &programs[0] {
+		t.Errorf("expected programs compiled again for a new generation")
+	}
+	changed, _ := p.compile(placement, `["cluster.name == 'eu-large'"]`)
+	if &changed[0] == &compiled[0] {
+		t.Errorf("expected programs compiled again for a changed annotation")
+	}
+}
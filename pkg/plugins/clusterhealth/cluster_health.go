@@ -0,0 +1,201 @@
+package clusterhealth
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/clock"
+	clusterapiv1 "open-cluster-management.io/api/cluster/v1"
+	clusterapiv1beta1 "open-cluster-management.io/api/cluster/v1beta1"
+	"open-cluster-management.io/placement/pkg/controllers/framework"
+	"open-cluster-management.io/placement/pkg/plugins"
+)
+
+var _ plugins.Filter = &ClusterHealth{}
+var _ plugins.ClusterIndependent = &ClusterHealth{}
+var _ plugins.ClusterFieldsReader = &ClusterHealth{}
+var HealthClock = (clock.Clock)(clock.RealClock{})
+
+const (
+	// IgnoreClusterHealthAnnotation opts a placement out of the filter if it is "true".
+	IgnoreClusterHealthAnnotation = "cluster.open-cluster-management.io/ignore-cluster-health"
+
+	// DefaultGracePeriod is how long an unavailable cluster is still selected by default.
+	DefaultGracePeriod = time.Minute
+
+	// LeaseName is the name of the lease the agent of a cluster renews in the cluster namespace.
+	LeaseName = "managed-cluster-lease"
+
+	// leaseDurationTimes is how many lease durations the lease of a cluster may not be renewed
+	// before the cluster is unavailable, the same as the registration controller of the hub.
+	leaseDurationTimes = 5
+
+	// defaultLeaseDurationSeconds is the lease duration of the clusters which do not set it.
+	defaultLeaseDurationSeconds = 60
+
+	description = `
+	ClusterHealth filter excludes the clusters which are not accepted by the hub, or whose
+	ManagedClusterConditionAvailable condition is False or Unknown, or whose lease is not renewed
+	in 5 lease durations, for longer than the grace period. The clusters without the condition
+	are treated as Unknown since they are created. Placements
+	with the annotation cluster.open-cluster-management.io/ignore-cluster-health: "true" are not
+	filtered. It is not enabled by default, since the taint controller of the hub taints the
+	unavailable clusters already, and the placements may tolerate the taints.
+	`
+)
+
+// Args are the arguments of the ClusterHealth filter in the scheduler configuration.
+type Args struct {
+	// GracePeriod is how long a cluster is still selected after it becomes unavailable, so that
+	// the decisions are not changed by a short disconnection. The default is 1m.
+	// +optional
+	GracePeriod *metav1.Duration `json:"gracePeriod,omitempty"`
+}
+
+type ClusterHealth struct {
+	handle      plugins.Handle
+	gracePeriod time.Duration
+}
+
+func New(handle plugins.Handle, args *Args) (*ClusterHealth, error) {
+	gracePeriod := DefaultGracePeriod
+	if args != nil && args.GracePeriod != nil {
+		gracePeriod = args.GracePeriod.Duration
+	}
+	if gracePeriod < 0 {
+		return nil, fmt.Errorf("gracePeriod must not be negative")
+	}
+
+	return &ClusterHealth{
+		handle:      handle,
+		gracePeriod: gracePeriod,
+	}, nil
+}
+
+func (c *ClusterHealth) Name() string {
+	return reflect.TypeOf(*c).Name()
+}
+
+func (c *ClusterHealth) Description() string {
+	return description
+}
+
+// ClusterFields returns the acceptance and the conditions of the clusters.
+func (c *ClusterHealth) ClusterFields() []plugins.ClusterField {
+	return []plugins.ClusterField{plugins.ClusterFieldHubAcceptsClient, plugins.ClusterFieldConditions}
+}
+
+// ClusterIndependent returns true since each cluster is filtered separately.
+func (c *ClusterHealth) ClusterIndependent() bool {
+	return true
+}
+
+func (c *ClusterHealth) Filter(ctx context.Context, placement *clusterapiv1beta1.Placement, clusters []*clusterapiv1.ManagedCluster) (plugins.PluginFilterResult, *framework.Status) {
+	status := framework.NewStatus(c.Name(), framework.Success, "")
+	if isIgnored(placement) {
+		return plugins.PluginFilterResult{
+			Filtered: clusters,
+		}, status
+	}
+
+	matched := []*clusterapiv1.ManagedCluster{}
+	rejected := map[string]string{}
+	for _, cluster := range clusters {
+		reason, _ := c.clusterHealth(cluster)
+		if len(reason) > 0 {
+			rejected[cluster.Name] = reason
+			continue
+		}
+		matched = append(matched, cluster)
+	}
+
+	return plugins.PluginFilterResult{
+		Filtered: matched,
+		Rejected: rejected,
+	}, status
+}
+
+// RequeueAfter returns the earliest time the grace period of an unavailable cluster selected by
+// the placement expires, so that the placement is scheduled again to exclude the cluster. Only
+// the clusters in the decisions of the placement are checked, since the other clusters in grace
+// period are not selected and excluding them does not change the decisions. The leases are not
+// expected to expire, so a cluster whose lease expires is excluded in the next scheduling.
+func (c *ClusterHealth) RequeueAfter(ctx context.Context, placement *clusterapiv1beta1.Placement) (plugins.PluginRequeueResult, *framework.Status) {
+	status := framework.NewStatus(c.Name(), framework.Success, "")
+	if isIgnored(placement) || c.gracePeriod == 0 {
+		return plugins.PluginRequeueResult{}, status
+	}
+
+	result := plugins.PluginRequeueResult{}
+	for clusterName := range c.handle.Snapshot(ctx).PlacementClusters(placement.Namespace, placement.Name) {
+		cluster, err := plugins.ClusterLister(ctx, c.handle).Get(clusterName)
+		if errors.IsNotFound(err) {
+			continue
+		}
+		if err != nil {
+			return plugins.PluginRequeueResult{}, framework.NewStatus(c.Name(), framework.Error, err.Error())
+		}
+
+		reason, graceExpiry := c.clusterHealth(cluster)
+		if len(reason) > 0 || graceExpiry == nil {
+			continue
+		}
+		if result.RequeueTime == nil || graceExpiry.Before(*result.RequeueTime) {
+			result.RequeueTime = graceExpiry
+		}
+	}
+	return result, status
+}
+
+// clusterHealth returns why the cluster is excluded, or an empty reason if the cluster is
+// accepted by the hub and available, or it is unavailable within the grace period. The time the
+// grace period expires is returned in the latter case. A cluster is unavailable since its
+// Available condition is not true or its lease expires, whichever is earlier.
+func (c *ClusterHealth) clusterHealth(cluster *clusterapiv1.ManagedCluster) (string, *time.Time) {
+	if !cluster.Spec.HubAcceptsClient {
+		return "the cluster is not accepted by the hub", nil
+	}
+
+	var since *time.Time
+	reason := ""
+	condition := meta.FindStatusCondition(cluster.Status.Conditions, clusterapiv1.ManagedClusterConditionAvailable)
+	switch {
+	case condition == nil:
+		since = &cluster.CreationTimestamp.Time
+		reason = "the cluster has no Available condition"
+	case condition.Status != metav1.ConditionTrue:
+		since = &condition.LastTransitionTime.Time
+		reason = fmt.Sprintf("the Available condition of the cluster is %s", condition.Status)
+	}
+
+	// the clusters whose lease is not found are checked by their conditions only
+	if lease, err := c.handle.LeaseLister().Leases(cluster.Name).Get(LeaseName); err == nil && lease.Spec.RenewTime != nil {
+		leaseDurationSeconds := cluster.Spec.LeaseDurationSeconds
+		if leaseDurationSeconds == 0 {
+			leaseDurationSeconds = defaultLeaseDurationSeconds
+		}
+		leaseExpiry := lease.Spec.RenewTime.Add(time.Duration(leaseDurationTimes*leaseDurationSeconds) * time.Second)
+		if HealthClock.Now().After(leaseExpiry) && (since == nil || leaseExpiry.Before(*since)) {
+			since = &leaseExpiry
+			reason = fmt.Sprintf("the lease of the cluster is not renewed since %s", lease.Spec.RenewTime.UTC().Format(time.RFC3339))
+		}
+	}
+
+	if since == nil {
+		return "", nil
+	}
+	graceExpiry := since.Add(c.gracePeriod)
+	if HealthClock.Now().Before(graceExpiry) {
+		return "", &graceExpiry
+	}
+	return reason, nil
+}
+
+func isIgnored(placement *clusterapiv1beta1.Placement) bool {
+	return placement.GetAnnotations()[IgnoreClusterHealthAnnotation] == "true"
+}
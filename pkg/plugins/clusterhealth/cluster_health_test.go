@@ -0,0 +1,151 @@
+package clusterhealth
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	coordinationv1 "k8s.io/api/coordination/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	testingclock "k8s.io/utils/clock/testing"
+	clusterfake "open-cluster-management.io/api/client/cluster/clientset/versioned/fake"
+	clusterapiv1 "open-cluster-management.io/api/cluster/v1"
+	clusterapiv1beta1 "open-cluster-management.io/api/cluster/v1beta1"
+	testinghelpers "open-cluster-management.io/placement/pkg/helpers/testing"
+)
+
+var fakeTime = time.Date(2022, time.January, 01, 0, 0, 0, 0, time.UTC)
+
+func newClusters() []*clusterapiv1.ManagedCluster {
+	return []*clusterapiv1.ManagedCluster{
+		testinghelpers.NewManagedCluster("available").WithHubAcceptsClient(true).
+			WithCondition(clusterapiv1.ManagedClusterConditionAvailable, metav1.ConditionTrue, fakeTime.Add(-time.Hour)).Build(),
+		testinghelpers.NewManagedCluster("not-accepted").WithHubAcceptsClient(false).
+			WithCondition(clusterapiv1.ManagedClusterConditionAvailable, metav1.ConditionTrue, fakeTime.Add(-time.Hour)).Build(),
+		testinghelpers.NewManagedCluster("unavailable").WithHubAcceptsClient(true).
+			WithCondition(clusterapiv1.ManagedClusterConditionAvailable, metav1.ConditionFalse, fakeTime.Add(-time.Hour)).Build(),
+		testinghelpers.NewManagedCluster("unknown-in-grace-period").WithHubAcceptsClient(true).
+			WithCondition(clusterapiv1.ManagedClusterConditionAvailable, metav1.ConditionUnknown, fakeTime.Add(-30*time.Second)).Build(),
+		testinghelpers.NewManagedCluster("no-condition").WithHubAcceptsClient(true).Build(),
+		testinghelpers.NewManagedCluster("lease-expired").WithHubAcceptsClient(true).
+			WithCondition(clusterapiv1.ManagedClusterConditionAvailable, metav1.ConditionTrue, fakeTime.Add(-time.Hour)).Build(),
+		testinghelpers.NewManagedCluster("lease-renewed").WithHubAcceptsClient(true).
+			WithCondition(clusterapiv1.ManagedClusterConditionAvailable, metav1.ConditionTrue, fakeTime.Add(-time.Hour)).Build(),
+	}
+}
+
+func newLease(clusterName string, renewTime time.Time) *coordinationv1.Lease {
+	return &coordinationv1.Lease{
+		ObjectMeta: metav1.ObjectMeta{Name: LeaseName, Namespace: clusterName},
+		Spec:       coordinationv1.LeaseSpec{RenewTime: &metav1.MicroTime{Time: renewTime}},
+	}
+}
+
+func TestFilter(t *testing.T) {
+	cases := []struct {
+		name             string
+		placement        *clusterapiv1beta1.Placement
+		gracePeriod      *metav1.Duration
+		decisions        []string
+		expectedClusters []string
+		expectedRejected map[string]string
+		expectedRequeue  *time.Time
+	}{
+		{
+			name:             "default grace period",
+			placement:        testinghelpers.NewPlacement("test", "test").Build(),
+			decisions:        []string{"available", "unknown-in-grace-period"},
+			expectedClusters: []string{"available", "unknown-in-grace-period", "lease-renewed"},
+			expectedRejected: map[string]string{
+				"not-accepted":  "the cluster is not accepted by the hub",
+				"unavailable":   "the Available condition of the cluster is False",
+				"no-condition":  "the cluster has no Available condition",
+				"lease-expired": "the lease of the cluster is not renewed since 2021-12-31T23:00:00Z",
+			},
+			expectedRequeue: func() *time.Time { t := fakeTime.Add(30 * time.Second); return &t }(),
+		},
+		{
+			name:             "clusters in grace period not selected",
+			placement:        testinghelpers.NewPlacement("test", "test").Build(),
+			decisions:        []string{"available"},
+			expectedClusters: []string{"available", "unknown-in-grace-period", "lease-renewed"},
+		},
+		{
+			name:             "no grace period",
+			placement:        testinghelpers.NewPlacement("test", "test").Build(),
+			gracePeriod:      &metav1.Duration{},
+			expectedClusters: []string{"available", "lease-renewed"},
+		},
+		{
+			name:             "long grace period",
+			placement:        testinghelpers.NewPlacement("test", "test").Build(),
+			gracePeriod:      &metav1.Duration{Duration: 2 * time.Hour},
+			decisions:        []string{"available", "unavailable", "unknown-in-grace-period", "no-condition", "lease-expired", "deleted"},
+			expectedClusters: []string{"available", "unavailable", "unknown-in-grace-period", "no-condition", "lease-expired", "lease-renewed"},
+			expectedRequeue:  func() *time.Time { t := fakeTime.Add(time.Hour); return &t }(),
+		},
+		{
+			name: "opt out",
+			placement: testinghelpers.NewPlacementWithAnnotations("test", "test", map[string]string{
+				IgnoreClusterHealthAnnotation: "true",
+			}).Build(),
+			expectedClusters: []string{"available", "not-accepted", "unavailable", "unknown-in-grace-period", "no-condition", "lease-expired", "lease-renewed"},
+		},
+	}
+
+	HealthClock = testingclock.NewFakeClock(fakeTime)
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			clusters := newClusters()
+			// the clusters without conditions are unknown since they are created
+			clusters[4].CreationTimestamp = metav1.NewTime(fakeTime.Add(-time.Hour))
+
+			objs := []runtime.Object{}
+			for _, cluster := range clusters {
+				objs = append(objs, cluster)
+			}
+			objs = append(objs,
+				// the lease expires 5 lease durations after it is renewed
+				newLease("lease-expired", fakeTime.Add(-time.Hour)),
+				newLease("lease-renewed", fakeTime.Add(-time.Minute)),
+			)
+			objs = append(objs, testinghelpers.NewPlacementDecision("test", "test-decision-1").
+				WithLabel(clusterapiv1beta1.PlacementLabel, "test").WithDecisions(c.decisions...).Build())
+			p, err := New(testinghelpers.NewFakePluginHandle(t, clusterfake.NewSimpleClientset(), objs...), &Args{GracePeriod: c.gracePeriod})
+			if err != nil {
+				t.Fatalf("unexpected err: %v", err)
+			}
+
+			result, status := p.Filter(context.TODO(), c.placement, clusters)
+			if status.IsError() {
+				t.Fatalf("unexpected err: %v", status.AsError())
+			}
+			actual := []string{}
+			for _, cluster := range result.Filtered {
+				actual = append(actual, cluster.Name)
+			}
+			if !reflect.DeepEqual(actual, c.expectedClusters) {
+				t.Errorf("expected clusters %v, but got %v", c.expectedClusters, actual)
+			}
+			if c.expectedRejected != nil && !reflect.DeepEqual(result.Rejected, c.expectedRejected) {
+				t.Errorf("expected rejected clusters %v, but got %v", c.expectedRejected, result.Rejected)
+			}
+
+			requeue, status := p.RequeueAfter(context.TODO(), c.placement)
+			if status.IsError() {
+				t.Fatalf("unexpected err: %v", status.AsError())
+			}
+			if !reflect.DeepEqual(requeue.RequeueTime, c.expectedRequeue) {
+				t.Errorf("expected requeue time %v, but got %v", c.expectedRequeue, requeue.RequeueTime)
+			}
+		})
+	}
+}
+
+func TestNegativeGracePeriod(t *testing.T) {
+	if _, err := New(nil, &Args{GracePeriod: &metav1.Duration{Duration: -time.Second}}); err == nil {
+		t.Errorf("expected error for negative grace period")
+	}
+}
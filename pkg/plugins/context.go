@@ -0,0 +1,26 @@
+package plugins
+
+import (
+	"context"
+
+	clusterlisterv1 "open-cluster-management.io/api/client/cluster/listers/cluster/v1"
+)
+
+type clusterListerContextKey struct{}
+
+// WithClusterLister returns a copy of the context in which the plugins get the clusters with the
+// lister instead of the one of the handle, e.g. the clusters with hypothetical changes in a dry
+// run of the scheduling.
+func WithClusterLister(ctx context.Context, clusterLister clusterlisterv1.ManagedClusterLister) context.Context {
+	return context.WithValue(ctx, clusterListerContextKey{}, clusterLister)
+}
+
+// ClusterLister returns the cluster lister in the context, or the one of the handle if the
+// context has none. The plugins getting clusters other than the ones passed to them while
+// scheduling a placement should use it in place of Handle.ClusterLister.
+func ClusterLister(ctx context.Context, handle Handle) clusterlisterv1.ManagedClusterLister {
+	if clusterLister, ok := ctx.Value(clusterListerContextKey{}).(clusterlisterv1.ManagedClusterLister); ok {
+		return clusterLister
+	}
+	return handle.ClusterLister()
+}
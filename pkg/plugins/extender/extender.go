@@ -0,0 +1,336 @@
+package extender
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	restclient "k8s.io/client-go/rest"
+	clusterapiv1 "open-cluster-management.io/api/cluster/v1"
+	clusterapiv1beta1 "open-cluster-management.io/api/cluster/v1beta1"
+	"open-cluster-management.io/placement/pkg/controllers/framework"
+	"open-cluster-management.io/placement/pkg/plugins"
+)
+
+const (
+	description = `
+	Extender delegates filtering or scoring of clusters to an HTTP endpoint outside of the
+	placement controller. The endpoint receives the placement and the names of candidate
+	clusters, and returns the filtered cluster names or the score of each cluster.
+	`
+
+	// DefaultHTTPTimeout is used when HTTPTimeout of an extender is not set.
+	DefaultHTTPTimeout = 5 * time.Second
+)
+
+var _ plugins.Filter = &Extender{}
+var _ plugins.Prioritizer = &Extender{}
+
+// Config configures an HTTP extender in the scheduler configuration.
+type Config struct {
+	// Name is the name of the extender. It is used as the plugin name in the schedule results
+	// and as the builtIn name of the prioritizer in the PrioritizerPolicy of placements.
+	Name string `json:"name"`
+
+	// URLPrefix is the prefix of the extender endpoints, e.g. https://extender.example.com/api.
+	URLPrefix string `json:"urlPrefix"`
+
+	// FilterVerb is appended to URLPrefix for filter requests. The extender does not filter
+	// clusters if it is empty.
+	// +optional
+	FilterVerb string `json:"filterVerb,omitempty"`
+
+	// PrioritizeVerb is appended to URLPrefix for prioritize requests. The extender does not
+	// score clusters if it is empty.
+	// +optional
+	PrioritizeVerb string `json:"prioritizeVerb,omitempty"`
+
+	// Weight is the default weight of the extender prioritizer used in Additive mode.
+	// +optional
+	Weight int32 `json:"weight,omitempty"`
+
+	// HTTPTimeout is the timeout of each request to the extender. The default is 5s.
+	// +optional
+	HTTPTimeout metav1.Duration `json:"httpTimeout,omitempty"`
+
+	// Ignorable specifies whether failures of the extender are ignored. If it is true, the
+	// clusters are not filtered and get no score from the extender when the request fails.
+	// Otherwise the scheduling of the placement fails.
+	// +optional
+	Ignorable bool `json:"ignorable,omitempty"`
+
+	// TLSConfig specifies the transport layer security config.
+	// +optional
+	TLSConfig *TLSConfig `json:"tlsConfig,omitempty"`
+}
+
+// TLSConfig contains the settings to enable TLS with the extender.
+type TLSConfig struct {
+	// Insecure skips the verification of the server certificate.
+	// +optional
+	Insecure bool `json:"insecure,omitempty"`
+	// ServerName is passed to the server for SNI and is used to verify the server certificate.
+	// +optional
+	ServerName string `json:"serverName,omitempty"`
+	// CertFile is the path of the client certificate file.
+	// +optional
+	CertFile string `json:"certFile,omitempty"`
+	// KeyFile is the path of the client key file.
+	// +optional
+	KeyFile string `json:"keyFile,omitempty"`
+	// CAFile is the path of the trusted root certificates file for the server.
+	// +optional
+	CAFile string `json:"caFile,omitempty"`
+}
+
+// FilterArgs is the request body of filter and prioritize requests.
+type FilterArgs struct {
+	Placement    *clusterapiv1beta1.Placement `json:"placement"`
+	ClusterNames []string                     `json:"clusterNames"`
+}
+
+// FilterResult is the response body of filter requests.
+type FilterResult struct {
+	// ClusterNames are the clusters that pass the filter.
+	ClusterNames []string `json:"clusterNames"`
+	// FailedClusters maps the clusters that do not pass the filter to the failure reasons.
+	FailedClusters map[string]string `json:"failedClusters,omitempty"`
+	// Error is set if the extender fails to filter the clusters.
+	Error string `json:"error,omitempty"`
+}
+
+// PrioritizeResult is the response body of prioritize requests.
+type PrioritizeResult struct {
+	// Scores maps the cluster names to their scores, which are clamped to [-100, 100].
+	Scores map[string]int64 `json:"scores"`
+	// Error is set if the extender fails to score the clusters.
+	Error string `json:"error,omitempty"`
+}
+
+// Extender is a filter and prioritizer backed by an HTTP endpoint.
+type Extender struct {
+	config Config
+	client *http.Client
+}
+
+// New returns an extender with the given config.
+func New(config Config) (*Extender, error) {
+	timeout := config.HTTPTimeout.Duration
+	if timeout == 0 {
+		timeout = DefaultHTTPTimeout
+	}
+
+	transport, err := makeTransport(config.TLSConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Extender{
+		config: config,
+		client: &http.Client{
+			Transport: transport,
+			Timeout:   timeout,
+		},
+	}, nil
+}
+
+func makeTransport(config *TLSConfig) (http.RoundTripper, error) {
+	restConfig := &restclient.Config{}
+	if config != nil {
+		restConfig.TLSClientConfig = restclient.TLSClientConfig{
+			Insecure:   config.Insecure,
+			ServerName: config.ServerName,
+			CertFile:   config.CertFile,
+			KeyFile:    config.KeyFile,
+			CAFile:     config.CAFile,
+		}
+	}
+	return restclient.TransportFor(restConfig)
+}
+
+// ValidateConfig validates the extender config.
+func ValidateConfig(config Config, path *field.Path) field.ErrorList {
+	errs := field.ErrorList{}
+	if len(config.Name) == 0 {
+		errs = append(errs, field.Required(path.Child("name"), "extender name is required"))
+	}
+	if u, err := url.Parse(config.URLPrefix); err != nil || len(u.Scheme) == 0 || len(u.Host) == 0 {
+		errs = append(errs, field.Invalid(path.Child("urlPrefix"), config.URLPrefix, "must be an absolute URL"))
+	}
+	if len(config.FilterVerb) == 0 && len(config.PrioritizeVerb) == 0 {
+		errs = append(errs, field.Required(path, "at least one of filterVerb and prioritizeVerb is required"))
+	}
+	if config.Weight < -10 || config.Weight > 10 {
+		errs = append(errs, field.Invalid(path.Child("weight"), config.Weight, "must be in the range [-10, 10]"))
+	}
+	if config.HTTPTimeout.Duration < 0 {
+		errs = append(errs, field.Invalid(path.Child("httpTimeout"), config.HTTPTimeout.Duration.String(), "must not be negative"))
+	}
+	if _, err := makeTransport(config.TLSConfig); err != nil {
+		errs = append(errs, field.Invalid(path.Child("tlsConfig"), config.TLSConfig, err.Error()))
+	}
+	return errs
+}
+
+func (e *Extender) Name() string {
+	return e.config.Name
+}
+
+func (e *Extender) Description() string {
+	return description
+}
+
+// IsFilter returns true if the extender filters clusters.
+func (e *Extender) IsFilter() bool {
+	return len(e.config.FilterVerb) > 0
+}
+
+// IsPrioritizer returns true if the extender scores clusters.
+func (e *Extender) IsPrioritizer() bool {
+	return len(e.config.PrioritizeVerb) > 0
+}
+
+// Weight returns the default weight of the extender prioritizer.
+func (e *Extender) Weight() int32 {
+	return e.config.Weight
+}
+
+func (e *Extender) Filter(ctx context.Context, placement *clusterapiv1beta1.Placement, clusters []*clusterapiv1.ManagedCluster) (plugins.PluginFilterResult, *framework.Status) {
+	if !e.IsFilter() || len(clusters) == 0 {
+		return plugins.PluginFilterResult{
+			Filtered: clusters,
+		}, framework.NewStatus(e.Name(), framework.Success, "")
+	}
+
+	result := &FilterResult{}
+	err := e.send(ctx, e.config.FilterVerb, placement, clusters, result)
+	if err == nil && len(result.Error) > 0 {
+		err = fmt.Errorf("%s", result.Error)
+	}
+	if err != nil {
+		return plugins.PluginFilterResult{
+			Filtered: clusters,
+		}, e.failureStatus(err)
+	}
+
+	// keep the order of the given clusters
+	passed := map[string]bool{}
+	for _, name := range result.ClusterNames {
+		passed[name] = true
+	}
+	filtered := []*clusterapiv1.ManagedCluster{}
+	rejected := map[string]string{}
+	for _, cluster := range clusters {
+		if passed[cluster.Name] {
+			filtered = append(filtered, cluster)
+			continue
+		}
+		reason, ok := result.FailedClusters[cluster.Name]
+		if !ok || len(reason) == 0 {
+			reason = fmt.Sprintf("the cluster is filtered out by extender %s without a reason", e.Name())
+		}
+		rejected[cluster.Name] = reason
+	}
+
+	return plugins.PluginFilterResult{
+		Filtered: filtered,
+		Rejected: rejected,
+	}, framework.NewStatus(e.Name(), framework.Success, "")
+}
+
+func (e *Extender) Score(ctx context.Context, placement *clusterapiv1beta1.Placement, clusters []*clusterapiv1.ManagedCluster) (plugins.PluginScoreResult, *framework.Status) {
+	scores := map[string]int64{}
+	for _, cluster := range clusters {
+		scores[cluster.Name] = 0
+	}
+	if !e.IsPrioritizer() || len(clusters) == 0 {
+		return plugins.PluginScoreResult{
+			Scores: scores,
+		}, framework.NewStatus(e.Name(), framework.Success, "")
+	}
+
+	result := &PrioritizeResult{}
+	err := e.send(ctx, e.config.PrioritizeVerb, placement, clusters, result)
+	if err == nil && len(result.Error) > 0 {
+		err = fmt.Errorf("%s", result.Error)
+	}
+	if err != nil {
+		return plugins.PluginScoreResult{
+			Scores: scores,
+		}, e.failureStatus(err)
+	}
+
+	// ignore the scores of clusters which are not candidates, and clamp the others to the range
+	// of the built-in prioritizers so that a single extender does not outweigh them
+	for name, score := range result.Scores {
+		if _, ok := scores[name]; !ok {
+			continue
+		}
+		switch {
+		case score > plugins.MaxClusterScore:
+			score = plugins.MaxClusterScore
+		case score < plugins.MinClusterScore:
+			score = plugins.MinClusterScore
+		}
+		scores[name] = score
+	}
+
+	return plugins.PluginScoreResult{
+		Scores: scores,
+	}, framework.NewStatus(e.Name(), framework.Success, "")
+}
+
+func (e *Extender) RequeueAfter(ctx context.Context, placement *clusterapiv1beta1.Placement) (plugins.PluginRequeueResult, *framework.Status) {
+	return plugins.PluginRequeueResult{}, framework.NewStatus(e.Name(), framework.Success, "")
+}
+
+// failureStatus returns a Warning status if the extender is ignorable, otherwise an Error status.
+func (e *Extender) failureStatus(err error) *framework.Status {
+	msg := fmt.Sprintf("extender %s failed: %v", e.Name(), err)
+	if e.config.Ignorable {
+		return framework.NewStatus(e.Name(), framework.Warning, msg)
+	}
+	return framework.NewStatus(e.Name(), framework.Error, msg)
+}
+
+// send posts the placement and cluster names to the extender and decodes the response into result.
+func (e *Extender) send(ctx context.Context, verb string, placement *clusterapiv1beta1.Placement, clusters []*clusterapiv1.ManagedCluster, result interface{}) error {
+	args := &FilterArgs{
+		Placement:    placement,
+		ClusterNames: []string{},
+	}
+	for _, cluster := range clusters {
+		args.ClusterNames = append(args.ClusterNames, cluster.Name)
+	}
+
+	body, err := json.Marshal(args)
+	if err != nil {
+		return err
+	}
+
+	u := strings.TrimRight(e.config.URLPrefix, "/") + "/" + verb
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, u, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := e.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("failed %v with extender at URL %v, code %v", verb, u, resp.StatusCode)
+	}
+
+	return json.NewDecoder(resp.Body).Decode(result)
+}
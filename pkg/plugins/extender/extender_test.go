@@ -0,0 +1,203 @@
+package extender
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	clusterapiv1 "open-cluster-management.io/api/cluster/v1"
+	"open-cluster-management.io/placement/pkg/controllers/framework"
+	testinghelpers "open-cluster-management.io/placement/pkg/helpers/testing"
+)
+
+func newTestServer(t *testing.T, delay time.Duration) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(delay)
+
+		args := &FilterArgs{}
+		if err := json.NewDecoder(r.Body).Decode(args); err != nil {
+			t.Errorf("unexpected err: %v", err)
+		}
+
+		switch r.URL.Path {
+		case "/filter":
+			// only clusters with even name length pass
+			result := &FilterResult{FailedClusters: map[string]string{}}
+			for _, name := range args.ClusterNames {
+				if len(name)%2 == 0 {
+					result.ClusterNames = append(result.ClusterNames, name)
+				} else {
+					result.FailedClusters[name] = "odd"
+				}
+			}
+			_ = json.NewEncoder(w).Encode(result)
+		case "/filter-without-reasons":
+			result := &FilterResult{}
+			for _, name := range args.ClusterNames {
+				if len(name)%2 == 0 {
+					result.ClusterNames = append(result.ClusterNames, name)
+				}
+			}
+			_ = json.NewEncoder(w).Encode(result)
+		case "/prioritize":
+			result := &PrioritizeResult{Scores: map[string]int64{"unknown": 100}}
+			for _, name := range args.ClusterNames {
+				result.Scores[name] = int64(len(name))
+			}
+			_ = json.NewEncoder(w).Encode(result)
+		case "/prioritize-out-of-range":
+			result := &PrioritizeResult{Scores: map[string]int64{}}
+			for _, name := range args.ClusterNames {
+				result.Scores[name] = int64(len(name)-3) * 1000
+			}
+			_ = json.NewEncoder(w).Encode(result)
+		case "/error":
+			_ = json.NewEncoder(w).Encode(&PrioritizeResult{Error: "internal error"})
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+}
+
+func TestExtender(t *testing.T) {
+	server := newTestServer(t, 0)
+	defer server.Close()
+	slowServer := newTestServer(t, 200*time.Millisecond)
+	defer slowServer.Close()
+
+	placement := testinghelpers.NewPlacement("test", "test").Build()
+	clusters := []*clusterapiv1.ManagedCluster{
+		testinghelpers.NewManagedCluster("c1").Build(),
+		testinghelpers.NewManagedCluster("c22").Build(),
+		testinghelpers.NewManagedCluster("c333").Build(),
+	}
+
+	cases := []struct {
+		name             string
+		config           Config
+		expectedFiltered []string
+		expectedRejected map[string]string
+		expectedScores   map[string]int64
+		expectedCode     framework.Code
+	}{
+		{
+			name:             "filter and prioritize",
+			config:           Config{Name: "test", URLPrefix: server.URL, FilterVerb: "filter", PrioritizeVerb: "prioritize"},
+			expectedFiltered: []string{"c1", "c333"},
+			expectedRejected: map[string]string{"c22": "odd"},
+			expectedScores:   map[string]int64{"c1": 2, "c22": 3, "c333": 4},
+			expectedCode:     framework.Success,
+		},
+		{
+			name:             "filter only",
+			config:           Config{Name: "test", URLPrefix: server.URL, FilterVerb: "filter"},
+			expectedFiltered: []string{"c1", "c333"},
+			expectedRejected: map[string]string{"c22": "odd"},
+			expectedScores:   map[string]int64{"c1": 0, "c22": 0, "c333": 0},
+			expectedCode:     framework.Success,
+		},
+		{
+			name:             "filter without reasons",
+			config:           Config{Name: "test", URLPrefix: server.URL, FilterVerb: "filter-without-reasons"},
+			expectedFiltered: []string{"c1", "c333"},
+			expectedRejected: map[string]string{"c22": "the cluster is filtered out by extender test without a reason"},
+			expectedScores:   map[string]int64{"c1": 0, "c22": 0, "c333": 0},
+			expectedCode:     framework.Success,
+		},
+		{
+			name:             "scores out of range",
+			config:           Config{Name: "test", URLPrefix: server.URL, PrioritizeVerb: "prioritize-out-of-range"},
+			expectedFiltered: []string{"c1", "c22", "c333"},
+			expectedScores:   map[string]int64{"c1": -100, "c22": 0, "c333": 100},
+			expectedCode:     framework.Success,
+		},
+		{
+			name:             "fatal failure",
+			config:           Config{Name: "test", URLPrefix: server.URL, FilterVerb: "notfound", PrioritizeVerb: "error"},
+			expectedFiltered: []string{"c1", "c22", "c333"},
+			expectedScores:   map[string]int64{"c1": 0, "c22": 0, "c333": 0},
+			expectedCode:     framework.Error,
+		},
+		{
+			name: "ignorable timeout",
+			config: Config{Name: "test", URLPrefix: slowServer.URL, FilterVerb: "filter", PrioritizeVerb: "prioritize",
+				HTTPTimeout: metav1.Duration{Duration: 50 * time.Millisecond}, Ignorable: true},
+			expectedFiltered: []string{"c1", "c22", "c333"},
+			expectedScores:   map[string]int64{"c1": 0, "c22": 0, "c333": 0},
+			expectedCode:     framework.Warning,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			e, err := New(c.config)
+			if err != nil {
+				t.Fatalf("unexpected err: %v", err)
+			}
+
+			filterResult, status := e.Filter(context.TODO(), placement, clusters)
+			if status.Code() != c.expectedCode {
+				t.Errorf("expected filter status code %v, but got %v: %s", c.expectedCode, status.Code(), status.Message())
+			}
+			filtered := []string{}
+			for _, cluster := range filterResult.Filtered {
+				filtered = append(filtered, cluster.Name)
+			}
+			if !reflect.DeepEqual(filtered, c.expectedFiltered) {
+				t.Errorf("expected filtered clusters %v, but got %v", c.expectedFiltered, filtered)
+			}
+			if len(filterResult.Rejected) > 0 || len(c.expectedRejected) > 0 {
+				if !reflect.DeepEqual(filterResult.Rejected, c.expectedRejected) {
+					t.Errorf("expected rejected clusters %v, but got %v", c.expectedRejected, filterResult.Rejected)
+				}
+			}
+
+			scoreResult, status := e.Score(context.TODO(), placement, clusters)
+			if status.Code() != c.expectedCode && c.config.PrioritizeVerb != "" {
+				t.Errorf("expected score status code %v, but got %v: %s", c.expectedCode, status.Code(), status.Message())
+			}
+			if !reflect.DeepEqual(scoreResult.Scores, c.expectedScores) {
+				t.Errorf("expected scores %v, but got %v", c.expectedScores, scoreResult.Scores)
+			}
+		})
+	}
+}
+
+func TestValidateConfig(t *testing.T) {
+	cases := []struct {
+		name           string
+		config         Config
+		expectedErrors int
+	}{
+		{
+			name:   "valid",
+			config: Config{Name: "test", URLPrefix: "https://extender.example.com/api", FilterVerb: "filter", Weight: 1},
+		},
+		{
+			name:           "invalid",
+			config:         Config{URLPrefix: "extender", Weight: 11},
+			expectedErrors: 4,
+		},
+		{
+			name: "missing CA file",
+			config: Config{Name: "test", URLPrefix: "https://extender.example.com/api", PrioritizeVerb: "prioritize",
+				TLSConfig: &TLSConfig{CAFile: "/not/exist"}},
+			expectedErrors: 1,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			errs := ValidateConfig(c.config, field.NewPath("extenders").Index(0))
+			if len(errs) != c.expectedErrors {
+				t.Errorf("expected %d errors, but got %v", c.expectedErrors, errs)
+			}
+		})
+	}
+}
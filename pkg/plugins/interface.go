@@ -5,6 +5,7 @@ import (
 	"math"
 	"time"
 
+	coordinationlisterv1 "k8s.io/client-go/listers/coordination/v1"
 	"k8s.io/client-go/tools/events"
 	clusterclient "open-cluster-management.io/api/client/cluster/clientset/versioned"
 	clusterlisterv1 "open-cluster-management.io/api/client/cluster/listers/cluster/v1"
@@ -13,6 +14,7 @@ import (
 	clusterapiv1 "open-cluster-management.io/api/cluster/v1"
 	clusterapiv1beta1 "open-cluster-management.io/api/cluster/v1beta1"
 	"open-cluster-management.io/placement/pkg/controllers/framework"
+	schedulingcache "open-cluster-management.io/placement/pkg/controllers/scheduling/cache"
 )
 
 const (
@@ -53,6 +55,105 @@ type Prioritizer interface {
 	Score(ctx context.Context, placement *clusterapiv1beta1.Placement, clusters []*clusterapiv1.ManagedCluster) (PluginScoreResult, *framework.Status)
 }
 
+// ClusterIndependent is implemented by a Filter or Prioritizer whose result for a cluster does
+// not depend on the other clusters. The scheduler may split the clusters into chunks and call
+// the plugin with each chunk concurrently.
+type ClusterIndependent interface {
+	// ClusterIndependent returns true if the clusters can be split into chunks.
+	ClusterIndependent() bool
+}
+
+// ClusterField is a field of ManagedCluster read by the plugins to schedule placements.
+type ClusterField string
+
+const (
+	// ClusterFieldLabels is the labels of the cluster.
+	ClusterFieldLabels ClusterField = "Labels"
+	// ClusterFieldClaims is the cluster claims in the status of the cluster.
+	ClusterFieldClaims ClusterField = "Claims"
+	// ClusterFieldTaints is the taints in the spec of the cluster.
+	ClusterFieldTaints ClusterField = "Taints"
+	// ClusterFieldResources is the allocatable and capacity resources of the cluster.
+	ClusterFieldResources ClusterField = "Resources"
+	// ClusterFieldVersion is the version of the cluster.
+	ClusterFieldVersion ClusterField = "Version"
+	// ClusterFieldConditions is the type, status and last transition time of the conditions
+	// of the cluster. The other fields of the conditions are not used for scheduling.
+	ClusterFieldConditions ClusterField = "Conditions"
+	// ClusterFieldHubAcceptsClient is whether the hub accepts the cluster.
+	ClusterFieldHubAcceptsClient ClusterField = "HubAcceptsClient"
+)
+
+// AllClusterFields are all the fields of ManagedCluster which can be read by the plugins.
+var AllClusterFields = []ClusterField{
+	ClusterFieldLabels,
+	ClusterFieldClaims,
+	ClusterFieldTaints,
+	ClusterFieldResources,
+	ClusterFieldVersion,
+	ClusterFieldConditions,
+	ClusterFieldHubAcceptsClient,
+}
+
+// ClusterFieldsReader is implemented by a plugin to declare the fields of ManagedCluster it
+// reads. The placements are not scheduled again when only the other fields of a cluster change.
+// A plugin which does not implement it is considered to read all the fields.
+type ClusterFieldsReader interface {
+	// ClusterFields returns the fields of ManagedCluster the plugin reads.
+	ClusterFields() []ClusterField
+}
+
+// PreFilter defines a plugin that is called before the filters. It validates the placement or
+// precomputes data for the scheduling cycle. The placement is not scheduled if it returns an
+// error status.
+type PreFilter interface {
+	Plugin
+
+	// PreFilter is called once per scheduling cycle of the placement before filtering clusters.
+	PreFilter(ctx context.Context, placement *clusterapiv1beta1.Placement) *framework.Status
+}
+
+// PostFilter defines a plugin that is called when the feasible clusters are fewer than the
+// NumberOfClusters of the placement.
+type PostFilter interface {
+	Plugin
+
+	// PostFilter receives all the candidate clusters and the feasible ones. It returns the new
+	// feasible clusters, or nil Filtered to keep them unchanged.
+	PostFilter(ctx context.Context, placement *clusterapiv1beta1.Placement,
+		clusters, feasible []*clusterapiv1.ManagedCluster) (PluginFilterResult, *framework.Status)
+}
+
+// ScoreNormalizer is implemented by a Prioritizer which normalizes the scores after the
+// clusters are scored and before they are weighted.
+type ScoreNormalizer interface {
+	// NormalizeScore updates the scores in place.
+	NormalizeScore(ctx context.Context, placement *clusterapiv1beta1.Placement, scores map[string]int64) *framework.Status
+}
+
+// Reserve defines a plugin that holds resources for the selected clusters before the decisions
+// are written to PlacementDecisions.
+type Reserve interface {
+	Plugin
+
+	// Reserve is called before binding the decisions. The decisions are not bound if it
+	// returns an error status.
+	Reserve(ctx context.Context, placement *clusterapiv1beta1.Placement, decisions []clusterapiv1beta1.ClusterDecision) *framework.Status
+
+	// Unreserve releases the resources held by Reserve. It is called if the Reserve of any plugin
+	// or the binding fails. It must be idempotent and may be called even if Reserve was not.
+	Unreserve(ctx context.Context, placement *clusterapiv1beta1.Placement, decisions []clusterapiv1beta1.ClusterDecision)
+}
+
+// PostBind defines a plugin that is notified after the decisions are written to
+// PlacementDecisions.
+type PostBind interface {
+	Plugin
+
+	// PostBind is informational, the returned status is only logged.
+	PostBind(ctx context.Context, placement *clusterapiv1beta1.Placement, decisions []clusterapiv1beta1.ClusterDecision) *framework.Status
+}
+
 // Handle provides data and some tools that plugins can use. It is
 // passed to the plugin factories at the time of plugin initialization.
 type Handle interface {
@@ -62,20 +163,32 @@ type Handle interface {
 	// ScoreLister lists all AddOnPlacementScores
 	ScoreLister() clusterlisterv1alpha1.AddOnPlacementScoreLister
 
-	// ClusterLister lists all ManagedClusters
+	// ClusterLister lists all ManagedClusters. The plugins scheduling a placement should get
+	// the clusters with ClusterLister(ctx, handle), which honors the lister in the context.
 	ClusterLister() clusterlisterv1.ManagedClusterLister
 
+	// LeaseLister lists the leases of the ManagedClusters, which are renewed by the agents
+	LeaseLister() coordinationlisterv1.LeaseLister
+
 	// ClusterClient returns the cluster client
 	ClusterClient() clusterclient.Interface
 
 	// EventRecorder returns an event recorder.
 	EventRecorder() events.EventRecorder
+
+	// Snapshot returns the snapshot of the decisions of all the placements, including the ones
+	// scheduled but not yet written. It returns the snapshot of the scheduling cycle in the
+	// context, which is taken once at the beginning of the cycle, or a new one if the context
+	// has none. It should be preferred to listing the placementdecisions.
+	Snapshot(ctx context.Context) *schedulingcache.Snapshot
 }
 
 // PluginFilterResult contains the details of a filter plugin result.
 type PluginFilterResult struct {
 	// Filtered contains the filtered ManagedCluster.
 	Filtered []*clusterapiv1.ManagedCluster
+	// Rejected optionally explains why the clusters are filtered out, keyed by cluster names.
+	Rejected map[string]string
 }
 
 // PluginScoreResult contains the details of a score plugin result.
@@ -0,0 +1,103 @@
+package kubernetesversion
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+
+	clusterapiv1 "open-cluster-management.io/api/cluster/v1"
+	clusterapiv1beta1 "open-cluster-management.io/api/cluster/v1beta1"
+	"open-cluster-management.io/placement/pkg/controllers/framework"
+	"open-cluster-management.io/placement/pkg/helpers/version"
+	"open-cluster-management.io/placement/pkg/plugins"
+)
+
+var _ plugins.Filter = &KubernetesVersion{}
+var _ plugins.ClusterIndependent = &KubernetesVersion{}
+var _ plugins.ClusterFieldsReader = &KubernetesVersion{}
+
+const (
+	// KubernetesVersionAnnotation is the annotation of a placement to select the clusters whose
+	// Kubernetes version is in the range, like ">=1.26 <1.29".
+	KubernetesVersionAnnotation = "cluster.open-cluster-management.io/kubernetes-version"
+
+	description = `
+	KubernetesVersion filter selects the clusters whose Kubernetes version matches the version range
+	in the annotation cluster.open-cluster-management.io/kubernetes-version of the placement. The
+	clusters which do not report a valid Kubernetes version are filtered out. All the clusters are
+	selected if the placement does not have the annotation.
+	`
+)
+
+type KubernetesVersion struct{}
+
+func New(handle plugins.Handle) *KubernetesVersion {
+	return &KubernetesVersion{}
+}
+
+func (k *KubernetesVersion) Name() string {
+	return reflect.TypeOf(*k).Name()
+}
+
+func (k *KubernetesVersion) Description() string {
+	return description
+}
+
+// ClusterFields returns the version since the other fields are not read.
+func (k *KubernetesVersion) ClusterFields() []plugins.ClusterField {
+	return []plugins.ClusterField{plugins.ClusterFieldVersion}
+}
+
+// ClusterIndependent returns true since each cluster is filtered separately.
+func (k *KubernetesVersion) ClusterIndependent() bool {
+	return true
+}
+
+func (k *KubernetesVersion) Filter(ctx context.Context, placement *clusterapiv1beta1.Placement, clusters []*clusterapiv1.ManagedCluster) (plugins.PluginFilterResult, *framework.Status) {
+	status := framework.NewStatus(k.Name(), framework.Success, "")
+
+	versionRange, ok := placement.GetAnnotations()[KubernetesVersionAnnotation]
+	if !ok {
+		return plugins.PluginFilterResult{
+			Filtered: clusters,
+		}, status
+	}
+
+	r, err := version.ParseRange(versionRange)
+	if err != nil {
+		return plugins.PluginFilterResult{}, framework.NewStatus(
+			k.Name(),
+			framework.Misconfigured,
+			fmt.Sprintf("invalid annotation %s %q: %v", KubernetesVersionAnnotation, versionRange, err),
+		)
+	}
+
+	matched := []*clusterapiv1.ManagedCluster{}
+	rejected := map[string]string{}
+	for _, cluster := range clusters {
+		kubeVersion := cluster.Status.Version.Kubernetes
+		if len(kubeVersion) == 0 {
+			rejected[cluster.Name] = "no Kubernetes version is reported"
+			continue
+		}
+
+		v, err := version.ParseVersion(kubeVersion)
+		switch {
+		case err != nil:
+			rejected[cluster.Name] = fmt.Sprintf("invalid Kubernetes version %q", kubeVersion)
+		case !r(v):
+			rejected[cluster.Name] = fmt.Sprintf("Kubernetes version %q is not in range %q", kubeVersion, versionRange)
+		default:
+			matched = append(matched, cluster)
+		}
+	}
+
+	return plugins.PluginFilterResult{
+		Filtered: matched,
+		Rejected: rejected,
+	}, status
+}
+
+func (k *KubernetesVersion) RequeueAfter(ctx context.Context, placement *clusterapiv1beta1.Placement) (plugins.PluginRequeueResult, *framework.Status) {
+	return plugins.PluginRequeueResult{}, framework.NewStatus(k.Name(), framework.Success, "")
+}
@@ -0,0 +1,84 @@
+package kubernetesversion
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	clusterapiv1 "open-cluster-management.io/api/cluster/v1"
+	"open-cluster-management.io/placement/pkg/controllers/framework"
+	testinghelpers "open-cluster-management.io/placement/pkg/helpers/testing"
+)
+
+func newCluster(name, kubeVersion string) *clusterapiv1.ManagedCluster {
+	cluster := testinghelpers.NewManagedCluster(name).Build()
+	cluster.Status.Version.Kubernetes = kubeVersion
+	return cluster
+}
+
+func TestFilter(t *testing.T) {
+	clusters := []*clusterapiv1.ManagedCluster{
+		newCluster("cluster1", "v1.25.9"),
+		newCluster("cluster2", "v1.26.0"),
+		newCluster("cluster3", "v1.28.3-eks-4f4795d"),
+		newCluster("cluster4", "v1.29.0+k3s1"),
+		newCluster("cluster5", ""),
+		newCluster("cluster6", "unknown"),
+	}
+
+	cases := []struct {
+		name             string
+		annotations      map[string]string
+		expectedCode     framework.Code
+		expectedClusters []string
+		expectedRejected map[string]string
+	}{
+		{
+			name:             "no annotation",
+			expectedClusters: []string{"cluster1", "cluster2", "cluster3", "cluster4", "cluster5", "cluster6"},
+		},
+		{
+			name:             "version range",
+			annotations:      map[string]string{KubernetesVersionAnnotation: ">=1.26 <1.29"},
+			expectedClusters: []string{"cluster2", "cluster3"},
+			expectedRejected: map[string]string{
+				"cluster1": `Kubernetes version "v1.25.9" is not in range ">=1.26 <1.29"`,
+				"cluster4": `Kubernetes version "v1.29.0+k3s1" is not in range ">=1.26 <1.29"`,
+				"cluster5": "no Kubernetes version is reported",
+				"cluster6": `invalid Kubernetes version "unknown"`,
+			},
+		},
+		{
+			name:         "invalid version range",
+			annotations:  map[string]string{KubernetesVersionAnnotation: ">=latest"},
+			expectedCode: framework.Misconfigured,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			placement := testinghelpers.NewPlacementWithAnnotations("test", "test", c.annotations).Build()
+			result, status := New(nil).Filter(context.TODO(), placement, clusters)
+			if status.Code() != c.expectedCode {
+				t.Fatalf("expected code %v, but got %v: %s", c.expectedCode, status.Code(), status.Message())
+			}
+			if status.IsError() {
+				return
+			}
+
+			actual := []string{}
+			for _, cluster := range result.Filtered {
+				actual = append(actual, cluster.Name)
+			}
+			if !reflect.DeepEqual(actual, c.expectedClusters) {
+				t.Errorf("expected clusters %v, but got %v", c.expectedClusters, actual)
+			}
+			if len(c.expectedRejected) == 0 && len(result.Rejected) == 0 {
+				return
+			}
+			if !reflect.DeepEqual(result.Rejected, c.expectedRejected) {
+				t.Errorf("expected rejected clusters %v, but got %v", c.expectedRejected, result.Rejected)
+			}
+		})
+	}
+}
@@ -2,6 +2,7 @@ package predicate
 
 import (
 	"context"
+	"fmt"
 	"reflect"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -14,6 +15,8 @@ import (
 )
 
 var _ plugins.Filter = &Predicate{}
+var _ plugins.ClusterIndependent = &Predicate{}
+var _ plugins.ClusterFieldsReader = &Predicate{}
 
 const description = "Predicate filter filters the clusters based on predicate defined in placement"
 
@@ -22,6 +25,7 @@ type Predicate struct{}
 type predicateSelector struct {
 	labelSelector labels.Selector
 	claimSelector labels.Selector
+	predicateExtension
 }
 
 func New(handle plugins.Handle) *Predicate {
@@ -36,11 +40,36 @@ func (p *Predicate) Description() string {
 	return description
 }
 
+// ClusterFields returns the labels and claims matched by the predicates.
+func (p *Predicate) ClusterFields() []plugins.ClusterField {
+	return []plugins.ClusterField{plugins.ClusterFieldLabels, plugins.ClusterFieldClaims}
+}
+
+// ClusterIndependent returns true since each cluster is filtered separately.
+func (p *Predicate) ClusterIndependent() bool {
+	return true
+}
+
 func (p *Predicate) Filter(
 	ctx context.Context, placement *clusterapiv1beta1.Placement, clusters []*clusterapiv1.ManagedCluster) (plugins.PluginFilterResult, *framework.Status) {
 	status := framework.NewStatus(p.Name(), framework.Success, "")
 
-	if len(placement.Spec.Predicates) == 0 {
+	extensions, err := parsePredicateExtensions(placement)
+	if err != nil {
+		return plugins.PluginFilterResult{}, framework.NewStatus(
+			p.Name(),
+			framework.Misconfigured,
+			err.Error(),
+		)
+	}
+
+	predicates := placement.Spec.Predicates
+	if len(predicates) == 0 && len(extensions) > 0 {
+		// an empty predicate selects all the clusters, and it is extended by the first extension
+		predicates = []clusterapiv1beta1.ClusterPredicate{{}}
+	}
+
+	if len(predicates) == 0 {
 		return plugins.PluginFilterResult{
 			Filtered: clusters,
 		}, status
@@ -53,7 +82,7 @@ func (p *Predicate) Filter(
 
 	// prebuild label/claim selectors for each predicate
 	predicateSelectors := []predicateSelector{}
-	for _, predicate := range placement.Spec.Predicates {
+	for i, predicate := range predicates {
 		// build label selector
 		labelSelector, err := convertLabelSelector(predicate.RequiredClusterSelector.LabelSelector)
 		if err != nil {
@@ -72,10 +101,14 @@ func (p *Predicate) Filter(
 				err.Error(),
 			)
 		}
-		predicateSelectors = append(predicateSelectors, predicateSelector{
+		ps := predicateSelector{
 			labelSelector: labelSelector,
 			claimSelector: claimSelector,
-		})
+		}
+		if i < len(extensions) {
+			ps.predicateExtension = extensions[i]
+		}
+		predicateSelectors = append(predicateSelectors, ps)
 	}
 
 	// match cluster with selectors one by one
@@ -91,6 +124,10 @@ func (p *Predicate) Filter(
 			if ok := ps.claimSelector.Matches(labels.Set(claims)); !ok {
 				continue
 			}
+			// match with typed expressions of the extension
+			if !matchesAll(ps.labelRequirements, cluster.Labels) || !matchesAll(ps.claimRequirements, claims) {
+				continue
+			}
 			matched = append(matched, cluster)
 			break
 		}
@@ -105,6 +142,31 @@ func (p *Predicate) RequeueAfter(ctx context.Context, placement *clusterapiv1bet
 	return plugins.PluginRequeueResult{}, framework.NewStatus(p.Name(), framework.Success, "")
 }
 
+// MayMatch returns true if the label and claim selectors of any predicate of the placement match
+// the cluster. The typed expressions of the predicate extensions are not evaluated, so a cluster
+// may be filtered out even if it returns true. An invalid selector is considered to match.
+func MayMatch(placement *clusterapiv1beta1.Placement, cluster *clusterapiv1.ManagedCluster) bool {
+	if len(placement.Spec.Predicates) == 0 {
+		return true
+	}
+
+	claims := getClusterClaims(cluster)
+	for _, predicate := range placement.Spec.Predicates {
+		labelSelector, err := convertLabelSelector(predicate.RequiredClusterSelector.LabelSelector)
+		if err != nil {
+			return true
+		}
+		claimSelector, err := convertClaimSelector(predicate.RequiredClusterSelector.ClaimSelector)
+		if err != nil {
+			return true
+		}
+		if labelSelector.Matches(labels.Set(cluster.Labels)) && claimSelector.Matches(labels.Set(claims)) {
+			return true
+		}
+	}
+	return false
+}
+
 // getClusterClaims returns a map containing cluster claims from the status of cluster
 func getClusterClaims(cluster *clusterapiv1.ManagedCluster) map[string]string {
 	claims := map[string]string{}
@@ -114,6 +176,23 @@ func getClusterClaims(cluster *clusterapiv1.ManagedCluster) map[string]string {
 	return claims
 }
 
+// Validate returns an error if the label selectors, the claim selectors or the predicate
+// extensions of the placement are invalid.
+func Validate(placement *clusterapiv1beta1.Placement) error {
+	if _, err := parsePredicateExtensions(placement); err != nil {
+		return err
+	}
+	for i, predicate := range placement.Spec.Predicates {
+		if _, err := convertLabelSelector(predicate.RequiredClusterSelector.LabelSelector); err != nil {
+			return fmt.Errorf("invalid predicates[%d].requiredClusterSelector.labelSelector: %v", i, err)
+		}
+		if _, err := convertClaimSelector(predicate.RequiredClusterSelector.ClaimSelector); err != nil {
+			return fmt.Errorf("invalid predicates[%d].requiredClusterSelector.claimSelector: %v", i, err)
+		}
+	}
+	return nil
+}
+
 // convertLabelSelector converts metav1.LabelSelector to labels.Selector
 func convertLabelSelector(labelSelector metav1.LabelSelector) (labels.Selector, error) {
 	selector, err := metav1.LabelSelectorAsSelector(&labelSelector)
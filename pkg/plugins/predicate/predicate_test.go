@@ -2,6 +2,7 @@ package predicate
 
 import (
 	"context"
+	"reflect"
 	"strings"
 	"testing"
 
@@ -10,6 +11,7 @@ import (
 
 	clusterapiv1 "open-cluster-management.io/api/cluster/v1"
 	clusterapiv1beta1 "open-cluster-management.io/api/cluster/v1beta1"
+	"open-cluster-management.io/placement/pkg/controllers/framework"
 	testinghelpers "open-cluster-management.io/placement/pkg/helpers/testing"
 )
 
@@ -130,3 +132,163 @@ func TestMatchWithClusterPredicates(t *testing.T) {
 	}
 
 }
+
+func TestMatchWithPredicateExtensions(t *testing.T) {
+	clusters := []*clusterapiv1.ManagedCluster{
+		testinghelpers.NewManagedCluster("cluster1").WithLabel("cloud", "Amazon").WithLabel("tier", "1").
+			WithClaim("node.count", "5").WithClaim("platform.version", "4.11.3").Build(),
+		testinghelpers.NewManagedCluster("cluster2").WithLabel("cloud", "Amazon").WithLabel("tier", "2").
+			WithClaim("node.count", "20").WithClaim("platform.version", "4.12.0").Build(),
+		testinghelpers.NewManagedCluster("cluster3").WithLabel("cloud", "Google").WithLabel("tier", "3").
+			WithClaim("node.count", "50").WithClaim("platform.version", "v4.14.1").Build(),
+		testinghelpers.NewManagedCluster("cluster4").WithLabel("cloud", "Google").WithLabel("tier", "gold").
+			WithClaim("node.count", "many").Build(),
+	}
+
+	cases := []struct {
+		name                 string
+		placement            *clusterapiv1beta1.Placement
+		expectedCode         framework.Code
+		expectedClusterNames []string
+	}{
+		{
+			name: "numeric claim without predicates",
+			placement: testinghelpers.NewPlacementWithAnnotations("test", "test", map[string]string{
+				PredicateExtensionsAnnotation: `[{"claimExpressions": [{"key": "node.count", "operator": "Gt", "values": ["10"]}]}]`,
+			}).Build(),
+			expectedClusterNames: []string{"cluster2", "cluster3"},
+		},
+		{
+			name: "semver claim and numeric label",
+			placement: testinghelpers.NewPlacementWithAnnotations("test", "test", map[string]string{
+				PredicateExtensionsAnnotation: `[{
+					"labelExpressions": [{"key": "tier", "operator": "Lte", "values": ["2"]}],
+					"claimExpressions": [{"key": "platform.version", "operator": "SemverGte", "values": ["4.12"]}]
+				}]`,
+			}).Build(),
+			expectedClusterNames: []string{"cluster2"},
+		},
+		{
+			name: "extension of the predicate at the same index",
+			placement: testinghelpers.NewPlacementWithAnnotations("test", "test", map[string]string{
+				PredicateExtensionsAnnotation: `[{}, {"claimExpressions": [{"key": "platform.version", "operator": "SemverLt", "values": ["4.12"]}]}]`,
+			}).AddPredicate(&metav1.LabelSelector{
+				MatchLabels: map[string]string{"cloud": "Google"},
+			}, nil).AddPredicate(&metav1.LabelSelector{
+				MatchLabels: map[string]string{"cloud": "Amazon"},
+			}, nil).Build(),
+			expectedClusterNames: []string{"cluster1", "cluster3", "cluster4"},
+		},
+		{
+			name: "invalid json",
+			placement: testinghelpers.NewPlacementWithAnnotations("test", "test", map[string]string{
+				PredicateExtensionsAnnotation: `{"claimExpressions": []}`,
+			}).Build(),
+			expectedCode: framework.Misconfigured,
+		},
+		{
+			name: "unknown operator",
+			placement: testinghelpers.NewPlacementWithAnnotations("test", "test", map[string]string{
+				PredicateExtensionsAnnotation: `[{"claimExpressions": [{"key": "node.count", "operator": "Between", "values": ["1"]}]}]`,
+			}).Build(),
+			expectedCode: framework.Misconfigured,
+		},
+		{
+			name: "non numeric value",
+			placement: testinghelpers.NewPlacementWithAnnotations("test", "test", map[string]string{
+				PredicateExtensionsAnnotation: `[{"labelExpressions": [{"key": "tier", "operator": "Gt", "values": ["gold"]}]}]`,
+			}).Build(),
+			expectedCode: framework.Misconfigured,
+		},
+		{
+			name: "invalid semver value",
+			placement: testinghelpers.NewPlacementWithAnnotations("test", "test", map[string]string{
+				PredicateExtensionsAnnotation: `[{"claimExpressions": [{"key": "platform.version", "operator": "SemverGt", "values": ["latest"]}]}]`,
+			}).Build(),
+			expectedCode: framework.Misconfigured,
+		},
+		{
+			name: "multiple values",
+			placement: testinghelpers.NewPlacementWithAnnotations("test", "test", map[string]string{
+				PredicateExtensionsAnnotation: `[{"claimExpressions": [{"key": "node.count", "operator": "Gt", "values": ["1", "2"]}]}]`,
+			}).Build(),
+			expectedCode: framework.Misconfigured,
+		},
+		{
+			name: "more extensions than predicates",
+			placement: testinghelpers.NewPlacementWithAnnotations("test", "test", map[string]string{
+				PredicateExtensionsAnnotation: `[{}, {}]`,
+			}).AddPredicate(&metav1.LabelSelector{}, nil).Build(),
+			expectedCode: framework.Misconfigured,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			p := &Predicate{}
+			result, status := p.Filter(context.TODO(), c.placement, clusters)
+			if status.Code() != c.expectedCode {
+				t.Fatalf("expected code %v, but got %v: %s", c.expectedCode, status.Code(), status.Message())
+			}
+			if status.IsError() {
+				return
+			}
+
+			actual := []string{}
+			for _, cluster := range result.Filtered {
+				actual = append(actual, cluster.Name)
+			}
+			if !reflect.DeepEqual(actual, c.expectedClusterNames) {
+				t.Errorf("expected clusters %v, but got %v", c.expectedClusterNames, actual)
+			}
+		})
+	}
+}
+
+func TestValidate(t *testing.T) {
+	cases := []struct {
+		name          string
+		placement     *clusterapiv1beta1.Placement
+		expectedError bool
+	}{
+		{
+			name: "valid predicates",
+			placement: testinghelpers.NewPlacementWithAnnotations("test", "test", map[string]string{
+				PredicateExtensionsAnnotation: `[{"claimExpressions": [{"key": "node.count", "operator": "Gt", "values": ["10"]}]}]`,
+			}).AddPredicate(&metav1.LabelSelector{MatchLabels: map[string]string{"env": "prod"}}, nil).Build(),
+		},
+		{
+			name: "invalid label selector",
+			placement: testinghelpers.NewPlacement("test", "test").AddPredicate(&metav1.LabelSelector{
+				MatchExpressions: []metav1.LabelSelectorRequirement{{Key: "env", Operator: "Like"}},
+			}, nil).Build(),
+			expectedError: true,
+		},
+		{
+			name: "invalid claim selector",
+			placement: testinghelpers.NewPlacement("test", "test").AddPredicate(nil, &clusterapiv1beta1.ClusterClaimSelector{
+				MatchExpressions: []metav1.LabelSelectorRequirement{{Key: "region", Operator: metav1.LabelSelectorOpIn}},
+			}).Build(),
+			expectedError: true,
+		},
+		{
+			name: "invalid extension",
+			placement: testinghelpers.NewPlacementWithAnnotations("test", "test", map[string]string{
+				PredicateExtensionsAnnotation: `[{"claimExpressions": [{"key": "node.count", "operator": "Between", "values": ["1"]}]}]`,
+			}).Build(),
+			expectedError: true,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			err := Validate(c.placement)
+			if c.expectedError && err == nil {
+				t.Errorf("expected error, but got nil")
+			}
+			if !c.expectedError && err != nil {
+				t.Errorf("unexpected err: %v", err)
+			}
+		})
+	}
+}
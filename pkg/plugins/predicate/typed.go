@@ -0,0 +1,182 @@
+package predicate
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	"github.com/blang/semver/v4"
+	clusterapiv1beta1 "open-cluster-management.io/api/cluster/v1beta1"
+	"open-cluster-management.io/placement/pkg/helpers/version"
+)
+
+// PredicateExtensionsAnnotation is the annotation of a placement to extend its predicates with
+// typed comparison expressions on cluster labels and claims. The value is a JSON list, the
+// extension at index i is ANDed with the predicate at the same index. If the placement has no
+// predicate, the first extension selects the clusters by itself. For example,
+//
+//	[{"claimExpressions": [{"key": "node.count", "operator": "Gt", "values": ["10"]}]}]
+const PredicateExtensionsAnnotation = "cluster.open-cluster-management.io/predicate-extensions"
+
+// TypedOperator compares a label or claim value with a number or a semantic version.
+type TypedOperator string
+
+const (
+	TypedOpGt        TypedOperator = "Gt"
+	TypedOpGte       TypedOperator = "Gte"
+	TypedOpLt        TypedOperator = "Lt"
+	TypedOpLte       TypedOperator = "Lte"
+	TypedOpSemverGt  TypedOperator = "SemverGt"
+	TypedOpSemverGte TypedOperator = "SemverGte"
+	TypedOpSemverLt  TypedOperator = "SemverLt"
+	TypedOpSemverLte TypedOperator = "SemverLte"
+)
+
+// PredicateExtension contains the typed expressions ANDed with a predicate.
+type PredicateExtension struct {
+	// LabelExpressions are the typed expressions on cluster labels.
+	// +optional
+	LabelExpressions []TypedExpression `json:"labelExpressions,omitempty"`
+	// ClaimExpressions are the typed expressions on cluster claims.
+	// +optional
+	ClaimExpressions []TypedExpression `json:"claimExpressions,omitempty"`
+}
+
+// TypedExpression requires the value of the key to compare with the only value by the operator.
+// The clusters without the key or with a value which can not be parsed do not match.
+type TypedExpression struct {
+	Key      string        `json:"key"`
+	Operator TypedOperator `json:"operator"`
+	Values   []string      `json:"values"`
+}
+
+// typedRequirement is a parsed TypedExpression.
+type typedRequirement struct {
+	key      string
+	operator TypedOperator
+	number   float64
+	version  semver.Version
+}
+
+// parsePredicateExtensions parses the annotation of the placement. An empty list is returned
+// if the placement does not have the annotation.
+func parsePredicateExtensions(placement *clusterapiv1beta1.Placement) ([]predicateExtension, error) {
+	value, ok := placement.GetAnnotations()[PredicateExtensionsAnnotation]
+	if !ok {
+		return nil, nil
+	}
+
+	extensions := []PredicateExtension{}
+	decoder := json.NewDecoder(bytes.NewReader([]byte(value)))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&extensions); err != nil {
+		return nil, fmt.Errorf("invalid annotation %s: %v", PredicateExtensionsAnnotation, err)
+	}
+	if numOfPredicates := len(placement.Spec.Predicates); len(extensions) > numOfPredicates && len(extensions) > 1 {
+		return nil, fmt.Errorf("invalid annotation %s: %d extensions for %d predicates",
+			PredicateExtensionsAnnotation, len(extensions), numOfPredicates)
+	}
+
+	result := []predicateExtension{}
+	for i, e := range extensions {
+		labelRequirements, err := parseTypedExpressions(e.LabelExpressions)
+		if err != nil {
+			return nil, fmt.Errorf("invalid annotation %s: [%d].labelExpressions: %v", PredicateExtensionsAnnotation, i, err)
+		}
+		claimRequirements, err := parseTypedExpressions(e.ClaimExpressions)
+		if err != nil {
+			return nil, fmt.Errorf("invalid annotation %s: [%d].claimExpressions: %v", PredicateExtensionsAnnotation, i, err)
+		}
+		result = append(result, predicateExtension{
+			labelRequirements: labelRequirements,
+			claimRequirements: claimRequirements,
+		})
+	}
+	return result, nil
+}
+
+// predicateExtension is a parsed PredicateExtension.
+type predicateExtension struct {
+	labelRequirements []typedRequirement
+	claimRequirements []typedRequirement
+}
+
+func parseTypedExpressions(expressions []TypedExpression) ([]typedRequirement, error) {
+	requirements := []typedRequirement{}
+	for i, e := range expressions {
+		if len(e.Key) == 0 {
+			return nil, fmt.Errorf("[%d].key: must be specified", i)
+		}
+		if len(e.Values) != 1 {
+			return nil, fmt.Errorf("[%d].values: must have exactly one value for operator %q", i, e.Operator)
+		}
+
+		r := typedRequirement{key: e.Key, operator: e.Operator}
+		var err error
+		switch e.Operator {
+		case TypedOpGt, TypedOpGte, TypedOpLt, TypedOpLte:
+			r.number, err = strconv.ParseFloat(e.Values[0], 64)
+		case TypedOpSemverGt, TypedOpSemverGte, TypedOpSemverLt, TypedOpSemverLte:
+			r.version, err = version.ParseVersion(e.Values[0])
+		default:
+			return nil, fmt.Errorf("[%d].operator: unsupported operator %q", i, e.Operator)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("[%d].values: invalid value %q for operator %q: %v", i, e.Values[0], e.Operator, err)
+		}
+		requirements = append(requirements, r)
+	}
+	return requirements, nil
+}
+
+// matches returns true if the value of the key in the set satisfies the requirement.
+func (r typedRequirement) matches(set map[string]string) bool {
+	value, ok := set[r.key]
+	if !ok {
+		return false
+	}
+
+	var cmp int
+	switch r.operator {
+	case TypedOpGt, TypedOpGte, TypedOpLt, TypedOpLte:
+		n, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return false
+		}
+		switch {
+		case n > r.number:
+			cmp = 1
+		case n < r.number:
+			cmp = -1
+		}
+	default:
+		v, err := version.ParseVersion(value)
+		if err != nil {
+			return false
+		}
+		cmp = v.Compare(r.version)
+	}
+
+	switch r.operator {
+	case TypedOpGt, TypedOpSemverGt:
+		return cmp > 0
+	case TypedOpGte, TypedOpSemverGte:
+		return cmp >= 0
+	case TypedOpLt, TypedOpSemverLt:
+		return cmp < 0
+	case TypedOpLte, TypedOpSemverLte:
+		return cmp <= 0
+	}
+	return false
+}
+
+// matchesAll returns true if the set satisfies all the requirements.
+func matchesAll(requirements []typedRequirement, set map[string]string) bool {
+	for _, r := range requirements {
+		if !r.matches(set) {
+			return false
+		}
+	}
+	return true
+}
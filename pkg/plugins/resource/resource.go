@@ -3,9 +3,11 @@ package resource
 import (
 	"context"
 	"fmt"
-	"regexp"
+	"math"
 	"sort"
+	"strings"
 
+	"k8s.io/apimachinery/pkg/util/validation"
 	clusterapiv1 "open-cluster-management.io/api/cluster/v1"
 	clusterapiv1beta1 "open-cluster-management.io/api/cluster/v1beta1"
 	"open-cluster-management.io/placement/pkg/controllers/framework"
@@ -15,14 +17,38 @@ import (
 const (
 	placementLabel = clusterapiv1beta1.PlacementLabel
 	description    = `
-	ResourceAllocatableCPU and ResourceAllocatableMemory prioritizer makes the scheduling 
-	decisions based on the resource allocatable of managed clusters. 
-	The clusters that has the most allocatable are given the highest score, 
-	while the least is given the lowest score.
+	Resource prioritizers make the scheduling decisions based on the resources of managed clusters.
+	The prioritizer name is Resource<Algorithm><Resource>, where the algorithm is one of
+	Allocatable, AllocatableRatio, LeastAllocated and MostAllocated, and the resource is CPU,
+	Memory or any other resource name in the allocatable of managed clusters, like nvidia.com/gpu.
+	Allocatable gives the clusters that has the most allocatable the highest score, while the least
+	is given the lowest score. AllocatableRatio scores the clusters by the ratio of allocatable to
+	capacity. LeastAllocated and MostAllocated give the clusters that has the least or the most
+	allocated ratio the highest score, which spreads the workloads or packs them onto busy clusters.
 	`
 )
 
+// The algorithms of resource prioritizers.
+const (
+	AlgorithmAllocatable      = "Allocatable"
+	AlgorithmAllocatableRatio = "AllocatableRatio"
+	AlgorithmLeastAllocated   = "LeastAllocated"
+	AlgorithmMostAllocated    = "MostAllocated"
+)
+
+const prioritizerNamePrefix = "Resource"
+
 var _ plugins.Prioritizer = &ResourcePrioritizer{}
+var _ plugins.ClusterFieldsReader = &ResourcePrioritizer{}
+
+// the algorithms are sorted by length descending, so that AllocatableRatio is matched before
+// Allocatable.
+var algorithms = []string{
+	AlgorithmAllocatableRatio,
+	AlgorithmLeastAllocated,
+	AlgorithmMostAllocated,
+	AlgorithmAllocatable,
+}
 
 var resourceMap = map[string]clusterapiv1.ResourceName{
 	"CPU":    clusterapiv1.ResourceCPU,
@@ -60,12 +86,37 @@ func (r *ResourcePrioritizerBuilder) Build() *ResourcePrioritizer {
 	return r.resourcePrioritizer
 }
 
+// IsResourcePrioritizer returns true if the prioritizerName is a valid resource prioritizer name.
+func IsResourcePrioritizer(prioritizerName string) bool {
+	algorithm, resource := parsePrioritizerName(prioritizerName)
+	return len(algorithm) > 0 && len(resource) > 0
+}
+
 // parese prioritizerName to algorithm and resource.
-// For example, prioritizerName ResourceAllocatableCPU will return Allocatable, CPU.
+// For example, prioritizerName ResourceAllocatableCPU will return Allocatable, CPU, and
+// ResourceMostAllocatednvidia.com/gpu will return MostAllocated, nvidia.com/gpu.
 func parsePrioritizerName(prioritizerName string) (algorithm string, resource clusterapiv1.ResourceName) {
-	s := regexp.MustCompile("[A-Z]+[a-z]*").FindAllString(prioritizerName, -1)
-	if len(s) == 3 {
-		return s[1], resourceMap[s[2]]
+	if !strings.HasPrefix(prioritizerName, prioritizerNamePrefix) {
+		return "", ""
+	}
+	name := strings.TrimPrefix(prioritizerName, prioritizerNamePrefix)
+	for _, a := range algorithms {
+		if !strings.HasPrefix(name, a) {
+			continue
+		}
+		r := strings.TrimPrefix(name, a)
+		if len(r) == 0 {
+			return "", ""
+		}
+		if mapped, ok := resourceMap[r]; ok {
+			return a, mapped
+		}
+		// the other resources are in the form of the resource names of the cluster, like pods or
+		// nvidia.com/gpu, so a misspelled name like Cpu is not taken as a resource without scores.
+		if strings.ToLower(r) != r || len(validation.IsQualifiedName(r)) > 0 {
+			return "", ""
+		}
+		return a, clusterapiv1.ResourceName(r)
 	}
 	return "", ""
 }
@@ -78,10 +129,22 @@ func (r *ResourcePrioritizer) Description() string {
 	return description
 }
 
+// ClusterFields returns the resources since the other fields are not read.
+func (r *ResourcePrioritizer) ClusterFields() []plugins.ClusterField {
+	return []plugins.ClusterField{plugins.ClusterFieldResources}
+}
+
 func (r *ResourcePrioritizer) Score(ctx context.Context, placement *clusterapiv1beta1.Placement, clusters []*clusterapiv1.ManagedCluster) (plugins.PluginScoreResult, *framework.Status) {
 	status := framework.NewStatus(r.Name(), framework.Success, "")
-	if r.algorithm == "Allocatable" {
+	switch r.algorithm {
+	case AlgorithmAllocatable:
 		return mostResourceAllocatableScores(r.resource, clusters), status
+	case AlgorithmAllocatableRatio:
+		return resourceAllocatableRatioScores(r.resource, clusters), status
+	case AlgorithmLeastAllocated:
+		return resourceAllocatedScores(r.resource, clusters, false), status
+	case AlgorithmMostAllocated:
+		return resourceAllocatedScores(r.resource, clusters, true), status
 	}
 	return plugins.PluginScoreResult{}, status
 }
@@ -125,6 +188,80 @@ func mostResourceAllocatableScores(resourceName clusterapiv1.ResourceName, clust
 	}
 }
 
+// Calculate clusters scores based on the ratio of resource allocatable to capacity.
+// The clusters that has all the capacity allocatable are given the highest score, while the ones
+// with nothing allocatable are given the lowest score. The clusters with no capacity are not scored.
+// The score range is from -100 to 100.
+func resourceAllocatableRatioScores(resourceName clusterapiv1.ResourceName, clusters []*clusterapiv1.ManagedCluster) plugins.PluginScoreResult {
+	scores := map[string]int64{}
+
+	for _, cluster := range clusters {
+		ratio, err := getClusterAllocatableRatio(cluster, resourceName)
+		if err != nil {
+			continue
+		}
+
+		// score = (resource_x_allocatable / resource_x_capacity - 0.5) * 2 * 100
+		scores[cluster.Name] = int64((ratio - 0.5) * 2.0 * 100.0)
+	}
+
+	return plugins.PluginScoreResult{
+		Scores: scores,
+	}
+}
+
+// Calculate clusters scores based on the allocated ratio of the resource, which is
+// 1 - allocatable / capacity. If mostAllocated is false, the clusters that has the least allocated
+// ratio are given the highest score, while the most is given the lowest score. If mostAllocated is
+// true, the scores are reversed to pack the workloads onto the clusters already in use.
+// The score range is from -100 to 100.
+func resourceAllocatedScores(resourceName clusterapiv1.ResourceName, clusters []*clusterapiv1.ManagedCluster, mostAllocated bool) plugins.PluginScoreResult {
+	scores := map[string]int64{}
+
+	allocated := map[string]float64{}
+	minAllocated, maxAllocated := math.MaxFloat64, -math.MaxFloat64
+	for _, cluster := range clusters {
+		ratio, err := getClusterAllocatableRatio(cluster, resourceName)
+		if err != nil {
+			continue
+		}
+		allocated[cluster.Name] = 1 - ratio
+		minAllocated = math.Min(minAllocated, allocated[cluster.Name])
+		maxAllocated = math.Max(maxAllocated, allocated[cluster.Name])
+	}
+
+	for clusterName, a := range allocated {
+		if maxAllocated == minAllocated {
+			scores[clusterName] = 100
+			continue
+		}
+
+		// score = ((max(resource_x_allocated) - resource_x_allocated) / (max(resource_x_allocated) - min(resource_x_allocated)) - 0.5) * 2 * 100
+		ratio := (maxAllocated - a) / (maxAllocated - minAllocated)
+		if mostAllocated {
+			ratio = 1 - ratio
+		}
+		scores[clusterName] = int64((ratio - 0.5) * 2.0 * 100.0)
+	}
+
+	return plugins.PluginScoreResult{
+		Scores: scores,
+	}
+}
+
+// getClusterAllocatableRatio returns the ratio of allocatable to capacity of the resourceName,
+// which is between 0 and 1.
+func getClusterAllocatableRatio(cluster *clusterapiv1.ManagedCluster, resourceName clusterapiv1.ResourceName) (float64, error) {
+	allocatable, capacity, err := getClusterResource(cluster, resourceName)
+	if err != nil {
+		return 0, err
+	}
+	if capacity <= 0 {
+		return 0, fmt.Errorf("no capacity %s found in cluster %s", resourceName, cluster.ObjectMeta.Name)
+	}
+	return math.Max(0, math.Min(1, allocatable/capacity)), nil
+}
+
 // Go through one cluster resources and return the allocatable and capacity of the resourceName.
 func getClusterResource(cluster *clusterapiv1.ManagedCluster, resourceName clusterapiv1.ResourceName) (allocatable, capacity float64, err error) {
 	if v, exist := cluster.Status.Allocatable[resourceName]; exist {
@@ -117,6 +117,67 @@ func TestScoreClusterWithResource(t *testing.T) {
 			},
 			expectedScores: map[string]int64{},
 		},
+		{
+			name:      "scores of ResourceAllocatableRatioCPU",
+			resource:  clusterapiv1.ResourceCPU,
+			algorithm: "AllocatableRatio",
+			placement: testinghelpers.NewPlacement("test", "test").Build(),
+			clusters: []*clusterapiv1.ManagedCluster{
+				testinghelpers.NewManagedCluster("cluster1").WithResource(clusterapiv1.ResourceCPU, "10", "10").Build(),
+				testinghelpers.NewManagedCluster("cluster2").WithResource(clusterapiv1.ResourceCPU, "6", "20").Build(),
+				testinghelpers.NewManagedCluster("cluster3").WithResource(clusterapiv1.ResourceCPU, "0", "10").Build(),
+				testinghelpers.NewManagedCluster("cluster4").WithResource(clusterapiv1.ResourceCPU, "1", "0").Build(),
+			},
+			expectedScores: map[string]int64{"cluster1": 100, "cluster2": -40, "cluster3": -100},
+		},
+		{
+			name:      "scores of ResourceLeastAllocatedMemory",
+			resource:  clusterapiv1.ResourceMemory,
+			algorithm: "LeastAllocated",
+			placement: testinghelpers.NewPlacement("test", "test").Build(),
+			clusters: []*clusterapiv1.ManagedCluster{
+				testinghelpers.NewManagedCluster("cluster1").WithResource(clusterapiv1.ResourceMemory, "80", "100").Build(),
+				testinghelpers.NewManagedCluster("cluster2").WithResource(clusterapiv1.ResourceMemory, "100", "200").Build(),
+				testinghelpers.NewManagedCluster("cluster3").WithResource(clusterapiv1.ResourceMemory, "20", "100").Build(),
+				testinghelpers.NewManagedCluster("cluster4").Build(),
+			},
+			expectedScores: map[string]int64{"cluster1": 100, "cluster2": 0, "cluster3": -100},
+		},
+		{
+			name:      "scores of ResourceMostAllocatedMemory",
+			resource:  clusterapiv1.ResourceMemory,
+			algorithm: "MostAllocated",
+			placement: testinghelpers.NewPlacement("test", "test").Build(),
+			clusters: []*clusterapiv1.ManagedCluster{
+				testinghelpers.NewManagedCluster("cluster1").WithResource(clusterapiv1.ResourceMemory, "80", "100").Build(),
+				testinghelpers.NewManagedCluster("cluster2").WithResource(clusterapiv1.ResourceMemory, "100", "200").Build(),
+				testinghelpers.NewManagedCluster("cluster3").WithResource(clusterapiv1.ResourceMemory, "20", "100").Build(),
+			},
+			expectedScores: map[string]int64{"cluster1": -100, "cluster2": 0, "cluster3": 100},
+		},
+		{
+			name:      "scores of ResourceMostAllocated with same allocated ratio",
+			resource:  clusterapiv1.ResourceMemory,
+			algorithm: "MostAllocated",
+			placement: testinghelpers.NewPlacement("test", "test").Build(),
+			clusters: []*clusterapiv1.ManagedCluster{
+				testinghelpers.NewManagedCluster("cluster1").WithResource(clusterapiv1.ResourceMemory, "50", "100").Build(),
+				testinghelpers.NewManagedCluster("cluster2").WithResource(clusterapiv1.ResourceMemory, "100", "200").Build(),
+			},
+			expectedScores: map[string]int64{"cluster1": 100, "cluster2": 100},
+		},
+		{
+			name:      "scores of GPU allocatable",
+			resource:  "nvidia.com/gpu",
+			algorithm: "Allocatable",
+			placement: testinghelpers.NewPlacement("test", "test").Build(),
+			clusters: []*clusterapiv1.ManagedCluster{
+				testinghelpers.NewManagedCluster("cluster1").WithResource("nvidia.com/gpu", "8", "8").Build(),
+				testinghelpers.NewManagedCluster("cluster2").WithResource("nvidia.com/gpu", "0", "8").Build(),
+				testinghelpers.NewManagedCluster("cluster3").WithResource(clusterapiv1.ResourceCPU, "10", "10").Build(),
+			},
+			expectedScores: map[string]int64{"cluster1": 100, "cluster2": -100},
+		},
 	}
 
 	for _, c := range cases {
@@ -141,3 +202,38 @@ func TestScoreClusterWithResource(t *testing.T) {
 		})
 	}
 }
+
+func TestParsePrioritizerName(t *testing.T) {
+	cases := []struct {
+		prioritizerName   string
+		expectedAlgorithm string
+		expectedResource  clusterapiv1.ResourceName
+	}{
+		{"ResourceAllocatableCPU", "Allocatable", clusterapiv1.ResourceCPU},
+		{"ResourceAllocatableMemory", "Allocatable", clusterapiv1.ResourceMemory},
+		{"ResourceAllocatableRatioCPU", "AllocatableRatio", clusterapiv1.ResourceCPU},
+		{"ResourceLeastAllocatedMemory", "LeastAllocated", clusterapiv1.ResourceMemory},
+		{"ResourceMostAllocatednvidia.com/gpu", "MostAllocated", "nvidia.com/gpu"},
+		{"ResourceAllocatablepods", "Allocatable", "pods"},
+		{"ResourceAllocatableephemeral-storage", "Allocatable", "ephemeral-storage"},
+		{"ResourceAllocatable", "", ""},
+		{"ResourceAllocatableCpu", "", ""},
+		{"ResourceMostAllocatedGPU", "", ""},
+		{"ResourceAllocatablenvidia.com/gpu/v1", "", ""},
+		{"ResourceUnknownCPU", "", ""},
+		{"AllocatableCPU", "", ""},
+		{"Balance", "", ""},
+	}
+
+	for _, c := range cases {
+		t.Run(c.prioritizerName, func(t *testing.T) {
+			algorithm, resource := parsePrioritizerName(c.prioritizerName)
+			if algorithm != c.expectedAlgorithm || resource != c.expectedResource {
+				t.Errorf("expected %q, %q, but got %q, %q", c.expectedAlgorithm, c.expectedResource, algorithm, resource)
+			}
+			if IsResourcePrioritizer(c.prioritizerName) != (len(c.expectedAlgorithm) > 0) {
+				t.Errorf("unexpected IsResourcePrioritizer result of %s", c.prioritizerName)
+			}
+		})
+	}
+}
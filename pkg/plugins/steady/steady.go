@@ -4,9 +4,6 @@ import (
 	"context"
 	"reflect"
 
-	"k8s.io/apimachinery/pkg/labels"
-	"k8s.io/apimachinery/pkg/selection"
-	"k8s.io/apimachinery/pkg/util/sets"
 	clusterapiv1 "open-cluster-management.io/api/cluster/v1"
 	clusterapiv1beta1 "open-cluster-management.io/api/cluster/v1beta1"
 	"open-cluster-management.io/placement/pkg/controllers/framework"
@@ -23,6 +20,8 @@ const (
 )
 
 var _ plugins.Prioritizer = &Steady{}
+var _ plugins.ClusterIndependent = &Steady{}
+var _ plugins.ClusterFieldsReader = &Steady{}
 
 type Steady struct {
 	handle plugins.Handle
@@ -42,37 +41,20 @@ func (s *Steady) Description() string {
 	return description
 }
 
+// ClusterFields returns no field since the clusters are scored by the decisions.
+func (s *Steady) ClusterFields() []plugins.ClusterField {
+	return nil
+}
+
+// ClusterIndependent returns true since each cluster is scored separately.
+func (s *Steady) ClusterIndependent() bool {
+	return true
+}
+
 func (s *Steady) Score(
 	ctx context.Context, placement *clusterapiv1beta1.Placement, clusters []*clusterapiv1.ManagedCluster) (plugins.PluginScoreResult, *framework.Status) {
-	// query placementdecisions with label selector
 	scores := map[string]int64{}
-	requirement, err := labels.NewRequirement(placementLabel, selection.Equals, []string{placement.Name})
-
-	if err != nil {
-		return plugins.PluginScoreResult{}, framework.NewStatus(
-			s.Name(),
-			framework.Error,
-			err.Error(),
-		)
-	}
-
-	labelSelector := labels.NewSelector().Add(*requirement)
-	decisions, err := s.handle.DecisionLister().PlacementDecisions(placement.Namespace).List(labelSelector)
-
-	if err != nil {
-		return plugins.PluginScoreResult{}, framework.NewStatus(
-			s.Name(),
-			framework.Error,
-			err.Error(),
-		)
-	}
-
-	existingDecisions := sets.String{}
-	for _, decision := range decisions {
-		for _, d := range decision.Status.Decisions {
-			existingDecisions.Insert(d.ClusterName)
-		}
-	}
+	existingDecisions := s.handle.Snapshot(ctx).PlacementClusters(placement.Namespace, placement.Name)
 
 	for _, cluster := range clusters {
 		if existingDecisions.Has(cluster.Name) {
@@ -6,8 +6,6 @@ import (
 	"reflect"
 	"time"
 
-	"k8s.io/apimachinery/pkg/labels"
-	"k8s.io/apimachinery/pkg/selection"
 	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/klog/v2"
 	"k8s.io/utils/clock"
@@ -18,11 +16,21 @@ import (
 )
 
 var _ plugins.Filter = &TaintToleration{}
+var _ plugins.Prioritizer = &TaintToleration{}
+var _ plugins.ClusterIndependent = &TaintToleration{}
+var _ plugins.ClusterFieldsReader = &TaintToleration{}
 var TolerationClock = (clock.Clock)(clock.RealClock{})
 
 const (
 	placementLabel = "cluster.open-cluster-management.io/placement"
-	description    = "TaintToleration is a plugin that checks if a placement tolerates a managed cluster's taints"
+	description    = `
+	TaintToleration is a plugin that checks if a placement tolerates a managed cluster's taints.
+	As a prioritizer, it lowers the score of the clusters with PreferNoSelect taints which are not
+	tolerated by the placement, the more such taints the lower the score.
+	`
+
+	// preferNoSelectTaintPenalty is the score subtracted for each untolerated PreferNoSelect taint.
+	preferNoSelectTaintPenalty int64 = 50
 )
 
 type TaintToleration struct {
@@ -43,6 +51,16 @@ func (pl *TaintToleration) Description() string {
 	return description
 }
 
+// ClusterFields returns the taints since the other fields are not read.
+func (pl *TaintToleration) ClusterFields() []plugins.ClusterField {
+	return []plugins.ClusterField{plugins.ClusterFieldTaints}
+}
+
+// ClusterIndependent returns true since each cluster is filtered and scored separately.
+func (pl *TaintToleration) ClusterIndependent() bool {
+	return true
+}
+
 func (pl *TaintToleration) Filter(ctx context.Context, placement *clusterapiv1beta1.Placement, clusters []*clusterapiv1.ManagedCluster) (plugins.PluginFilterResult, *framework.Status) {
 	status := framework.NewStatus(pl.Name(), framework.Success, "")
 
@@ -70,7 +88,7 @@ func (pl *TaintToleration) Filter(ctx context.Context, placement *clusterapiv1be
 		}
 	}
 
-	decisionClusterNames := getDecisionClusterNames(pl.handle, placement)
+	decisionClusterNames := getDecisionClusterNames(ctx, pl.handle, placement)
 
 	// filter the clusters
 	matched := []*clusterapiv1.ManagedCluster{}
@@ -85,10 +103,28 @@ func (pl *TaintToleration) Filter(ctx context.Context, placement *clusterapiv1be
 	}, status
 }
 
+// Score gives 0 to the clusters without untolerated PreferNoSelect taints, and subtracts
+// preferNoSelectTaintPenalty for each untolerated PreferNoSelect taint of a cluster. The score range
+// is from -100 to 0.
+func (pl *TaintToleration) Score(ctx context.Context, placement *clusterapiv1beta1.Placement, clusters []*clusterapiv1.ManagedCluster) (plugins.PluginScoreResult, *framework.Status) {
+	scores := map[string]int64{}
+	for _, cluster := range clusters {
+		score := -preferNoSelectTaintPenalty * int64(countIntolerablePreferNoSelectTaints(cluster, placement.Spec.Tolerations))
+		if score < plugins.MinClusterScore {
+			score = plugins.MinClusterScore
+		}
+		scores[cluster.Name] = score
+	}
+
+	return plugins.PluginScoreResult{
+		Scores: scores,
+	}, framework.NewStatus(pl.Name(), framework.Success, "")
+}
+
 func (pl *TaintToleration) RequeueAfter(ctx context.Context, placement *clusterapiv1beta1.Placement) (plugins.PluginRequeueResult, *framework.Status) {
 	status := framework.NewStatus(pl.Name(), framework.Success, "")
 	// get exist decisions clusters
-	decisionClusterNames, decisionClusters := getDecisionClusters(pl.handle, placement)
+	decisionClusterNames, decisionClusters := getDecisionClusters(ctx, pl.handle, placement)
 	if decisionClusterNames == nil || decisionClusters == nil {
 		return plugins.PluginRequeueResult{}, status
 	}
@@ -124,6 +160,28 @@ func isClusterTolerated(cluster *clusterapiv1.ManagedCluster, tolerations []clus
 	return true, minRequeue, ""
 }
 
+// countIntolerablePreferNoSelectTaints returns the number of PreferNoSelect taints of the cluster
+// which are not tolerated by the given toleration array
+func countIntolerablePreferNoSelectTaints(cluster *clusterapiv1.ManagedCluster, tolerations []clusterapiv1beta1.Toleration) int {
+	count := 0
+	for _, taint := range cluster.Spec.Taints {
+		if taint.Effect != clusterapiv1.TaintEffectPreferNoSelect {
+			continue
+		}
+
+		tolerated := false
+		for _, toleration := range tolerations {
+			if tolerated, _, _ = isTolerated(taint, toleration); tolerated {
+				break
+			}
+		}
+		if !tolerated {
+			count++
+		}
+	}
+	return count
+}
+
 // isTaintTolerated returns true if a taint is tolerated by the given toleration array
 func isTaintTolerated(taint clusterapiv1.Taint, tolerations []clusterapiv1beta1.Toleration, inDecision bool) (bool, *plugins.PluginRequeueResult, string) {
 	message := ""
@@ -198,38 +256,18 @@ func isTolerationTimeExpired(taint clusterapiv1.Taint, toleration clusterapiv1be
 	return false, nil, ""
 }
 
-func getDecisionClusterNames(handle plugins.Handle, placement *clusterapiv1beta1.Placement) sets.String {
-	existingDecisions := sets.String{}
-
-	// query placementdecisions with label selector
-	requirement, err := labels.NewRequirement(placementLabel, selection.Equals, []string{placement.Name})
-	if err != nil {
-		return existingDecisions
-	}
-
-	labelSelector := labels.NewSelector().Add(*requirement)
-	decisions, err := handle.DecisionLister().PlacementDecisions(placement.Namespace).List(labelSelector)
-	if err != nil {
-		return existingDecisions
-	}
-
-	for _, decision := range decisions {
-		for _, d := range decision.Status.Decisions {
-			existingDecisions.Insert(d.ClusterName)
-		}
-	}
-
-	return existingDecisions
+func getDecisionClusterNames(ctx context.Context, handle plugins.Handle, placement *clusterapiv1beta1.Placement) sets.String {
+	return handle.Snapshot(ctx).PlacementClusters(placement.Namespace, placement.Name)
 }
 
-func getDecisionClusters(handle plugins.Handle, placement *clusterapiv1beta1.Placement) (sets.String, []*clusterapiv1.ManagedCluster) {
+func getDecisionClusters(ctx context.Context, handle plugins.Handle, placement *clusterapiv1beta1.Placement) (sets.String, []*clusterapiv1.ManagedCluster) {
 	// get existing decision cluster name
-	decisionClusterNames := getDecisionClusterNames(handle, placement)
+	decisionClusterNames := getDecisionClusterNames(ctx, handle, placement)
 
 	// get existing decision clusters
 	decisionClusters := []*clusterapiv1.ManagedCluster{}
 	for c := range decisionClusterNames {
-		if managedCluser, err := handle.ClusterLister().Get(c); err != nil {
+		if managedCluser, err := plugins.ClusterLister(ctx, handle).Get(c); err != nil {
 			klog.Warningf("Failed to get ManagedCluster: %s", err)
 		} else {
 			decisionClusters = append(decisionClusters, managedCluser)
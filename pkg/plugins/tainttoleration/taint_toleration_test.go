@@ -3,6 +3,7 @@ package tainttoleration
 import (
 	"context"
 	"errors"
+	"reflect"
 	"strings"
 	"testing"
 	"time"
@@ -784,3 +785,87 @@ func TestMatchWithClusterTaintToleration(t *testing.T) {
 	}
 
 }
+
+func TestScoreWithPreferNoSelectTaints(t *testing.T) {
+	preferNoSelect := func(key string) *clusterapiv1.Taint {
+		return &clusterapiv1.Taint{Key: key, Effect: clusterapiv1.TaintEffectPreferNoSelect, TimeAdded: metav1.NewTime(fakeTime)}
+	}
+
+	cases := []struct {
+		name           string
+		placement      *clusterapiv1beta1.Placement
+		clusters       []*clusterapiv1.ManagedCluster
+		expectedScores map[string]int64
+	}{
+		{
+			name:      "untolerated PreferNoSelect taints",
+			placement: testinghelpers.NewPlacement("test", "test").Build(),
+			clusters: []*clusterapiv1.ManagedCluster{
+				testinghelpers.NewManagedCluster("cluster1").Build(),
+				testinghelpers.NewManagedCluster("cluster2").WithTaint(preferNoSelect("key1")).Build(),
+				testinghelpers.NewManagedCluster("cluster3").WithTaint(preferNoSelect("key1")).WithTaint(preferNoSelect("key2")).Build(),
+				testinghelpers.NewManagedCluster("cluster4").WithTaint(preferNoSelect("key1")).WithTaint(preferNoSelect("key2")).
+					WithTaint(preferNoSelect("key3")).Build(),
+			},
+			expectedScores: map[string]int64{"cluster1": 0, "cluster2": -50, "cluster3": -100, "cluster4": -100},
+		},
+		{
+			name: "tolerated PreferNoSelect taints",
+			placement: testinghelpers.NewPlacement("test", "test").AddToleration(&clusterapiv1beta1.Toleration{
+				Key:      "key1",
+				Operator: clusterapiv1beta1.TolerationOpExists,
+			}).Build(),
+			clusters: []*clusterapiv1.ManagedCluster{
+				testinghelpers.NewManagedCluster("cluster1").WithTaint(preferNoSelect("key1")).Build(),
+				testinghelpers.NewManagedCluster("cluster2").WithTaint(preferNoSelect("key1")).WithTaint(preferNoSelect("key2")).Build(),
+			},
+			expectedScores: map[string]int64{"cluster1": 0, "cluster2": -50},
+		},
+		{
+			name: "expired toleration",
+			placement: testinghelpers.NewPlacement("test", "test").AddToleration(&clusterapiv1beta1.Toleration{
+				Key:               "key1",
+				Operator:          clusterapiv1beta1.TolerationOpExists,
+				TolerationSeconds: &tolerationSeconds_10,
+			}).Build(),
+			clusters: []*clusterapiv1.ManagedCluster{
+				testinghelpers.NewManagedCluster("cluster1").WithTaint(&clusterapiv1.Taint{
+					Key:       "key1",
+					Effect:    clusterapiv1.TaintEffectPreferNoSelect,
+					TimeAdded: metav1.NewTime(addedTime_10),
+				}).Build(),
+				testinghelpers.NewManagedCluster("cluster2").WithTaint(&clusterapiv1.Taint{
+					Key:       "key1",
+					Effect:    clusterapiv1.TaintEffectPreferNoSelect,
+					TimeAdded: metav1.NewTime(addedTime_9),
+				}).Build(),
+			},
+			expectedScores: map[string]int64{"cluster1": -50, "cluster2": 0},
+		},
+		{
+			name:      "other taints are not scored",
+			placement: testinghelpers.NewPlacement("test", "test").Build(),
+			clusters: []*clusterapiv1.ManagedCluster{
+				testinghelpers.NewManagedCluster("cluster1").WithTaint(&clusterapiv1.Taint{
+					Key:    "key1",
+					Effect: clusterapiv1.TaintEffectNoSelectIfNew,
+				}).Build(),
+			},
+			expectedScores: map[string]int64{"cluster1": 0},
+		},
+	}
+
+	TolerationClock = testingclock.NewFakeClock(fakeTime)
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			p := &TaintToleration{handle: testinghelpers.NewFakePluginHandle(t, nil)}
+			result, status := p.Score(context.TODO(), c.placement, c.clusters)
+			if status.IsError() {
+				t.Fatalf("unexpected err: %v", status.AsError())
+			}
+			if !reflect.DeepEqual(result.Scores, c.expectedScores) {
+				t.Errorf("expected scores %v, but got %v", c.expectedScores, result.Scores)
+			}
+		})
+	}
+}
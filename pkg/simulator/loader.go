@@ -0,0 +1,138 @@
+package simulator
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	utilyaml "k8s.io/apimachinery/pkg/util/yaml"
+	clusterscheme "open-cluster-management.io/api/client/cluster/clientset/versioned/scheme"
+	"sigs.k8s.io/yaml"
+)
+
+// LoadFiles loads the objects from YAML or JSON files. A file can contain multiple documents
+// separated by "---" and lists of kind List. The files with extensions .yaml, .yml and .json in
+// a directory are loaded, and "-" reads the standard input.
+func LoadFiles(paths ...string) ([]runtime.Object, error) {
+	objects := []runtime.Object{}
+	for _, path := range paths {
+		files, err := expandPath(path)
+		if err != nil {
+			return nil, err
+		}
+		for _, file := range files {
+			objs, err := loadFile(file)
+			if err != nil {
+				return nil, fmt.Errorf("failed to load %s: %v", file, err)
+			}
+			objects = append(objects, objs...)
+		}
+	}
+	return objects, nil
+}
+
+// expandPath returns the files in the directory if the path is a directory.
+func expandPath(path string) ([]string, error) {
+	if path == "-" {
+		return []string{path}, nil
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+	if !info.IsDir() {
+		return []string{path}, nil
+	}
+
+	entries, err := os.ReadDir(path)
+	if err != nil {
+		return nil, err
+	}
+	files := []string{}
+	for _, entry := range entries {
+		switch strings.ToLower(filepath.Ext(entry.Name())) {
+		case ".yaml", ".yml", ".json":
+			if !entry.IsDir() {
+				files = append(files, filepath.Join(path, entry.Name()))
+			}
+		}
+	}
+	return files, nil
+}
+
+func loadFile(file string) ([]runtime.Object, error) {
+	var reader io.Reader = os.Stdin
+	if file != "-" {
+		f, err := os.Open(file)
+		if err != nil {
+			return nil, err
+		}
+		defer f.Close()
+		reader = f
+	}
+	return Decode(reader)
+}
+
+// Decode decodes the objects from the YAML or JSON documents.
+func Decode(reader io.Reader) ([]runtime.Object, error) {
+	objects := []runtime.Object{}
+	yamlReader := utilyaml.NewYAMLReader(bufio.NewReader(reader))
+	for {
+		doc, err := yamlReader.Read()
+		if err == io.EOF {
+			return objects, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		if len(bytes.TrimSpace(doc)) == 0 {
+			continue
+		}
+
+		objs, err := decodeDocument(doc)
+		if err != nil {
+			return nil, err
+		}
+		objects = append(objects, objs...)
+	}
+}
+
+func decodeDocument(doc []byte) ([]runtime.Object, error) {
+	typeMeta := metav1.TypeMeta{}
+	if err := yaml.Unmarshal(doc, &typeMeta); err != nil {
+		return nil, err
+	}
+	// skip the documents which only contain comments
+	if len(typeMeta.Kind) == 0 && len(typeMeta.APIVersion) == 0 {
+		return nil, nil
+	}
+
+	if typeMeta.Kind != "List" {
+		obj, _, err := clusterscheme.Codecs.UniversalDeserializer().Decode(doc, nil, nil)
+		if err != nil {
+			return nil, err
+		}
+		return []runtime.Object{obj}, nil
+	}
+
+	list := metav1.List{}
+	if err := yaml.Unmarshal(doc, &list); err != nil {
+		return nil, err
+	}
+	objects := []runtime.Object{}
+	for _, item := range list.Items {
+		objs, err := decodeDocument(item.Raw)
+		if err != nil {
+			return nil, err
+		}
+		objects = append(objects, objs...)
+	}
+	return objects, nil
+}
@@ -0,0 +1,119 @@
+package simulator
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	clusterapiv1beta1 "open-cluster-management.io/api/cluster/v1beta1"
+
+	"open-cluster-management.io/placement/pkg/cmd/output"
+	scheduling "open-cluster-management.io/placement/pkg/controllers/scheduling"
+)
+
+// Print writes the results in the output format.
+func Print(w io.Writer, results []Result, format string) error {
+	return output.Print(w, results, format, func(w io.Writer) {
+		for i, result := range results {
+			if i > 0 {
+				fmt.Fprintln(w)
+			}
+			printTable(w, result)
+		}
+	})
+}
+
+// printTable prints the filter pipeline and the scores of the clusters of a placement.
+func printTable(w io.Writer, result Result) {
+	fmt.Fprintf(w, "Placement %s/%s: %d decisions, %d unscheduled\n",
+		result.Namespace, result.Name, len(result.Decisions), result.NumOfUnscheduled)
+	fmt.Fprintf(w, "Eligible clustersets: %s (%d clusters)\n", strings.Join(result.ClusterSets, ","), result.NumOfClusters)
+	if len(result.Error) > 0 {
+		fmt.Fprintf(w, "Error: %s\n", result.Error)
+	}
+
+	tw := tabwriter.NewWriter(w, 0, 8, 2, ' ', 0)
+	if len(result.FilterResults) > 0 {
+		fmt.Fprintln(tw)
+		fmt.Fprintln(tw, "FILTER\tCLUSTERS\tFILTERED OUT")
+		// each filter runs on the clusters passed the previous filters
+		numOfClusters := result.NumOfClusters
+		for _, r := range result.FilterResults {
+			names := strings.Split(r.Name, ",")
+			fmt.Fprintf(tw, "%s\t%d\t%d\n", names[len(names)-1], len(r.FilteredClusters), numOfClusters-len(r.FilteredClusters))
+			numOfClusters = len(r.FilteredClusters)
+		}
+	}
+
+	// the clusters filtered out with a reason, e.g. the ones without status by ClusterHealth
+	rejected := [][]string{}
+	for _, r := range result.FilterResults {
+		names := strings.Split(r.Name, ",")
+		clusters := []string{}
+		for cluster := range r.RejectedClusters {
+			clusters = append(clusters, cluster)
+		}
+		sort.Strings(clusters)
+		for _, cluster := range clusters {
+			rejected = append(rejected, []string{cluster, names[len(names)-1], r.RejectedClusters[cluster]})
+		}
+	}
+	if len(rejected) > 0 {
+		fmt.Fprintln(tw)
+		fmt.Fprintln(tw, "FILTERED CLUSTER\tFILTER\tREASON")
+		for _, row := range rejected {
+			fmt.Fprintln(tw, strings.Join(row, "\t"))
+		}
+	}
+
+	if len(result.Scores) > 0 {
+		selections := map[string]string{}
+		for _, decision := range result.Decisions {
+			selections[decision.ClusterName] = decisionSelection(decision)
+		}
+
+		clusters := []string{}
+		for cluster := range result.Scores {
+			clusters = append(clusters, cluster)
+		}
+		sort.Slice(clusters, func(i, j int) bool {
+			if result.Scores[clusters[i]] != result.Scores[clusters[j]] {
+				return result.Scores[clusters[i]] > result.Scores[clusters[j]]
+			}
+			return clusters[i] < clusters[j]
+		})
+
+		fmt.Fprintln(tw)
+		header := []string{"CLUSTER"}
+		for _, r := range result.PrioritizeResults {
+			header = append(header, fmt.Sprintf("%s(%d)", r.Name, r.Weight))
+		}
+		header = append(header, "TOTAL", "DECISION")
+		fmt.Fprintln(tw, strings.Join(header, "\t"))
+		for _, cluster := range clusters {
+			row := []string{cluster}
+			for _, r := range result.PrioritizeResults {
+				row = append(row, fmt.Sprintf("%d", r.Scores[cluster]))
+			}
+			selection, ok := selections[cluster]
+			if !ok {
+				selection = "-"
+			}
+			row = append(row, fmt.Sprintf("%d", result.Scores[cluster]), selection)
+			fmt.Fprintln(tw, strings.Join(row, "\t"))
+		}
+	}
+	tw.Flush()
+}
+
+// decisionSelection returns whether the cluster of the decision is kept or newly selected.
+func decisionSelection(decision clusterapiv1beta1.ClusterDecision) string {
+	reason := scheduling.DecisionReason{}
+	if err := json.Unmarshal([]byte(decision.Reason), &reason); err != nil || len(reason.Selection) == 0 {
+		return "Selected"
+	}
+	return reason.Selection
+}
@@ -0,0 +1,179 @@
+package simulator
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/sets"
+	coordinationlisterv1 "k8s.io/client-go/listers/coordination/v1"
+	"k8s.io/client-go/tools/cache"
+	kevents "k8s.io/client-go/tools/events"
+	clusterfake "open-cluster-management.io/api/client/cluster/clientset/versioned/fake"
+	clusterinformers "open-cluster-management.io/api/client/cluster/informers/externalversions"
+	clusterapiv1 "open-cluster-management.io/api/cluster/v1"
+	clusterapiv1alpha1 "open-cluster-management.io/api/cluster/v1alpha1"
+	clusterapiv1beta1 "open-cluster-management.io/api/cluster/v1beta1"
+	clusterapiv1beta2 "open-cluster-management.io/api/cluster/v1beta2"
+
+	scheduling "open-cluster-management.io/placement/pkg/controllers/scheduling"
+	schedulingcache "open-cluster-management.io/placement/pkg/controllers/scheduling/cache"
+)
+
+// Simulator schedules placements offline with the objects loaded from files. The objects are
+// kept in memory and no API server is needed.
+type Simulator struct {
+	informers  clusterinformers.SharedInformerFactory
+	scheduler  scheduling.Scheduler
+	placements []*clusterapiv1beta1.Placement
+}
+
+// Result is the result of scheduling a placement.
+type Result struct {
+	Namespace string `json:"namespace"`
+	Name      string `json:"name"`
+	// ClusterSets are the clustersets eligible for the placement.
+	ClusterSets []string `json:"clusterSets"`
+	// NumOfClusters is the number of clusters in the eligible clustersets.
+	NumOfClusters int `json:"numOfClusters"`
+	// Decisions are the clusters selected by the placement.
+	Decisions []clusterapiv1beta1.ClusterDecision `json:"decisions"`
+	// NumOfUnscheduled is the number of the decisions which could not be scheduled.
+	NumOfUnscheduled int `json:"numOfUnscheduled,omitempty"`
+	// Scores are the total scores of the feasible clusters.
+	Scores            scheduling.PrioritizerScore    `json:"scores,omitempty"`
+	FilterResults     []scheduling.FilterResult      `json:"filterResults,omitempty"`
+	PrioritizeResults []scheduling.PrioritizerResult `json:"prioritizeResults,omitempty"`
+	Error             string                         `json:"error,omitempty"`
+}
+
+// New builds a simulator with the objects and the scheduler configuration. The existing
+// placementdecisions and addonplacementscores are visible to the plugins as they are on the hub.
+// The clusters in files usually have no status, so they are filtered out if the ClusterHealth
+// filter is enabled in the configuration; the reasons are in the filter results of the output.
+func New(objects []runtime.Object, config *scheduling.SchedulerConfiguration) (*Simulator, error) {
+	clusterClient := clusterfake.NewSimpleClientset()
+	informers := clusterinformers.NewSharedInformerFactory(clusterClient, 10*time.Minute)
+	decisionCache := schedulingcache.NewCache()
+
+	s := &Simulator{informers: informers}
+	for _, obj := range objects {
+		var store cache.Store
+		switch o := obj.(type) {
+		case *clusterapiv1.ManagedCluster:
+			store = informers.Cluster().V1().ManagedClusters().Informer().GetStore()
+		case *clusterapiv1beta2.ManagedClusterSet:
+			store = informers.Cluster().V1beta2().ManagedClusterSets().Informer().GetStore()
+		case *clusterapiv1beta2.ManagedClusterSetBinding:
+			store = informers.Cluster().V1beta2().ManagedClusterSetBindings().Informer().GetStore()
+		case *clusterapiv1beta1.Placement:
+			store = informers.Cluster().V1beta1().Placements().Informer().GetStore()
+			s.placements = append(s.placements, o)
+		case *clusterapiv1beta1.PlacementDecision:
+			store = informers.Cluster().V1beta1().PlacementDecisions().Informer().GetStore()
+			decisionCache.OnAdd(o)
+		case *clusterapiv1alpha1.AddOnPlacementScore:
+			store = informers.Cluster().V1alpha1().AddOnPlacementScores().Informer().GetStore()
+		default:
+			return nil, fmt.Errorf("unsupported object %T", obj)
+		}
+		if err := store.Add(obj); err != nil {
+			return nil, err
+		}
+	}
+	sort.Slice(s.placements, func(i, j int) bool {
+		if s.placements[i].Namespace != s.placements[j].Namespace {
+			return s.placements[i].Namespace < s.placements[j].Namespace
+		}
+		return s.placements[i].Name < s.placements[j].Name
+	})
+
+	scheduler, err := scheduling.NewPluginSchedulerWithConfig(
+		scheduling.NewSchedulerHandler(
+			clusterClient,
+			informers.Cluster().V1beta1().PlacementDecisions().Lister(),
+			informers.Cluster().V1alpha1().AddOnPlacementScores().Lister(),
+			informers.Cluster().V1().ManagedClusters().Lister(),
+			// the leases are not loaded, so the clusters are checked by their conditions only
+			coordinationlisterv1.NewLeaseLister(cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})),
+			decisionCache,
+			&kevents.FakeRecorder{}),
+		config,
+	)
+	if err != nil {
+		return nil, err
+	}
+	s.scheduler = scheduler
+
+	return s, nil
+}
+
+// Simulate schedules the placements one by one in the order of namespace/name, and the
+// decisions of each placement are reserved for the next ones as the scheduling controller
+// does. Only the placements whose namespace/name keys are in the names are scheduled if any.
+func (s *Simulator) Simulate(ctx context.Context, names ...string) ([]Result, error) {
+	keys := sets.NewString(names...)
+	found := sets.NewString()
+	results := []Result{}
+	for _, placement := range s.placements {
+		key := placement.Namespace + "/" + placement.Name
+		if keys.Len() > 0 && !keys.Has(key) {
+			continue
+		}
+		found.Insert(key)
+
+		result, err := s.simulate(ctx, placement)
+		if err != nil {
+			return nil, fmt.Errorf("failed to simulate placement %s: %v", key, err)
+		}
+		results = append(results, result)
+	}
+	if missing := keys.Difference(found); missing.Len() > 0 {
+		return nil, fmt.Errorf("placements not found: %v", missing.List())
+	}
+
+	return results, nil
+}
+
+func (s *Simulator) simulate(ctx context.Context, placement *clusterapiv1beta1.Placement) (Result, error) {
+	result := Result{
+		Namespace: placement.Namespace,
+		Name:      placement.Name,
+	}
+
+	eligible, err := scheduling.GetEligibleClusters(
+		s.informers.Cluster().V1().ManagedClusters().Lister(),
+		s.informers.Cluster().V1beta2().ManagedClusterSets().Lister(),
+		s.informers.Cluster().V1beta2().ManagedClusterSetBindings().Lister(),
+		placement,
+	)
+	if err != nil {
+		return result, err
+	}
+	result.ClusterSets = eligible.ClusterSets
+	result.NumOfClusters = len(eligible.Clusters)
+
+	// sort the clusters so that the results are the same for the same objects
+	sort.Slice(eligible.Clusters, func(i, j int) bool {
+		return eligible.Clusters[i].Name < eligible.Clusters[j].Name
+	})
+
+	scheduleResult, status := s.scheduler.Schedule(ctx, placement, eligible.Clusters)
+	if status.IsError() {
+		result.Error = status.AsError().Error()
+	}
+	if binder, ok := s.scheduler.(scheduling.BindingPlugins); ok {
+		if reserveStatus := binder.Reserve(ctx, placement, scheduleResult.Decisions()); reserveStatus.IsError() {
+			return result, reserveStatus.AsError()
+		}
+	}
+
+	result.Decisions = scheduleResult.Decisions()
+	result.NumOfUnscheduled = scheduleResult.NumOfUnscheduled()
+	result.Scores = scheduleResult.PrioritizerScores()
+	result.FilterResults = scheduleResult.FilterResults()
+	result.PrioritizeResults = scheduleResult.PrioritizerResults()
+	return result, nil
+}
@@ -0,0 +1,235 @@
+package simulator
+
+import (
+	"bytes"
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clusterapiv1beta1 "open-cluster-management.io/api/cluster/v1beta1"
+	clusterapiv1beta2 "open-cluster-management.io/api/cluster/v1beta2"
+
+	"open-cluster-management.io/placement/pkg/cmd/output"
+	scheduling "open-cluster-management.io/placement/pkg/controllers/scheduling"
+	testinghelpers "open-cluster-management.io/placement/pkg/helpers/testing"
+)
+
+const objectsYAML = `
+apiVersion: cluster.open-cluster-management.io/v1
+kind: ManagedCluster
+metadata:
+  name: cluster1
+  labels:
+    cluster.open-cluster-management.io/clusterset: prod
+    cloud: Amazon
+---
+# comments only
+---
+{"apiVersion": "cluster.open-cluster-management.io/v1beta1", "kind": "Placement", "metadata": {"namespace": "default", "name": "placement1"}}
+---
+apiVersion: v1
+kind: List
+items:
+- apiVersion: cluster.open-cluster-management.io/v1beta2
+  kind: ManagedClusterSet
+  metadata:
+    name: prod
+- apiVersion: cluster.open-cluster-management.io/v1beta2
+  kind: ManagedClusterSetBinding
+  metadata:
+    namespace: default
+    name: prod
+  spec:
+    clusterSet: prod
+`
+
+func TestDecode(t *testing.T) {
+	objects, err := Decode(strings.NewReader(objectsYAML))
+	if err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+
+	actual := []string{}
+	for _, obj := range objects {
+		accessor, _ := obj.(metav1.Object)
+		actual = append(actual, reflect.TypeOf(obj).Elem().Name()+"/"+accessor.GetName())
+	}
+	expected := []string{
+		"ManagedCluster/cluster1",
+		"Placement/placement1",
+		"ManagedClusterSet/prod",
+		"ManagedClusterSetBinding/prod",
+	}
+	if !reflect.DeepEqual(actual, expected) {
+		t.Errorf("expected objects %v, but got %v", expected, actual)
+	}
+
+	if _, err := Decode(strings.NewReader("apiVersion: v1\nkind: ConfigMap\n")); err == nil {
+		t.Errorf("expected error for an unknown kind")
+	}
+}
+
+func newObjects() []runtime.Object {
+	return []runtime.Object{
+		testinghelpers.NewClusterSet("prod").Build(),
+		testinghelpers.NewClusterSetBinding("default", "prod"),
+		testinghelpers.NewManagedCluster("cluster1").WithLabel(clusterapiv1beta2.ClusterSetLabel, "prod").WithLabel("cloud", "Amazon").Build(),
+		testinghelpers.NewManagedCluster("cluster2").WithLabel(clusterapiv1beta2.ClusterSetLabel, "prod").WithLabel("cloud", "Amazon").Build(),
+		testinghelpers.NewManagedCluster("cluster3").WithLabel(clusterapiv1beta2.ClusterSetLabel, "prod").WithLabel("cloud", "Google").Build(),
+		testinghelpers.NewManagedCluster("cluster4").WithLabel(clusterapiv1beta2.ClusterSetLabel, "dev").Build(),
+		testinghelpers.NewPlacement("default", "placement1").WithNOC(1).AddPredicate(&metav1.LabelSelector{
+			MatchLabels: map[string]string{"cloud": "Amazon"},
+		}, nil).Build(),
+		testinghelpers.NewPlacement("default", "placement2").WithNOC(2).Build(),
+		testinghelpers.NewPlacement("other", "placement3").Build(),
+		// cluster2 is already selected by placement1
+		testinghelpers.NewPlacementDecision("default", "placement1-decision-1").
+			WithLabel(clusterapiv1beta1.PlacementLabel, "placement1").WithDecisions("cluster2").Build(),
+	}
+}
+
+func TestSimulate(t *testing.T) {
+	s, err := New(newObjects(), scheduling.NewDefaultSchedulerConfiguration())
+	if err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+
+	results, err := s.Simulate(context.TODO())
+	if err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+
+	type summary struct {
+		key           string
+		clusterSets   []string
+		numOfClusters int
+		decisions     []string
+	}
+	actual := []summary{}
+	for _, r := range results {
+		decisions := []string{}
+		for _, d := range r.Decisions {
+			decisions = append(decisions, d.ClusterName)
+		}
+		actual = append(actual, summary{r.Namespace + "/" + r.Name, r.ClusterSets, r.NumOfClusters, decisions})
+	}
+	expected := []summary{
+		{"default/placement1", []string{"prod"}, 3, []string{"cluster2"}},
+		{"default/placement2", []string{"prod"}, 3, []string{"cluster1", "cluster3"}},
+		{"other/placement3", []string{}, 0, []string{}},
+	}
+	if !reflect.DeepEqual(actual, expected) {
+		t.Errorf("expected results %v, but got %v", expected, actual)
+	}
+
+	predicateResult := results[0].FilterResults[0]
+	if predicateResult.Name != "Predicate" || !reflect.DeepEqual(predicateResult.FilteredClusters, []string{"cluster1", "cluster2"}) {
+		t.Errorf("expected cluster1 and cluster2 filtered by Predicate, but got %v", predicateResult)
+	}
+
+	// only the specified placements are simulated
+	results, err = s.Simulate(context.TODO(), "other/placement3")
+	if err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+	if len(results) != 1 || results[0].Name != "placement3" {
+		t.Errorf("expected placement3 simulated, but got %v", results)
+	}
+	if _, err := s.Simulate(context.TODO(), "default/missing"); err == nil {
+		t.Errorf("expected error for a missing placement")
+	}
+}
+
+func TestSimulateClustersWithoutStatus(t *testing.T) {
+	objects, err := Decode(strings.NewReader(objectsYAML))
+	if err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+
+	// the cluster without conditions is kept by the default configuration
+	s, err := New(objects, scheduling.NewDefaultSchedulerConfiguration())
+	if err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+	results, err := s.Simulate(context.TODO())
+	if err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+	if len(results) != 1 || len(results[0].Decisions) != 1 || results[0].Decisions[0].ClusterName != "cluster1" {
+		t.Errorf("expected cluster1 selected, but got %v", results)
+	}
+
+	// and filtered out with a reason if ClusterHealth is enabled
+	config := scheduling.NewDefaultSchedulerConfiguration()
+	config.Filters.Enabled = append(config.Filters.Enabled, scheduling.Plugin{Name: scheduling.FilterClusterHealth})
+	s, err = New(objects, config)
+	if err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+	results, err = s.Simulate(context.TODO())
+	if err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+	if len(results) != 1 || len(results[0].Decisions) != 0 {
+		t.Fatalf("expected no decisions, but got %v", results)
+	}
+
+	out := &bytes.Buffer{}
+	if err := Print(out, results, output.Table); err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+	expected := `FILTERED CLUSTER  FILTER         REASON
+cluster1          ClusterHealth  the cluster is not accepted by the hub
+`
+	if !strings.HasSuffix(out.String(), expected) {
+		t.Errorf("expected output ending with:\n%s\nbut got:\n%s", expected, out.String())
+	}
+}
+
+func TestPrint(t *testing.T) {
+	s, err := New(newObjects(), scheduling.NewDefaultSchedulerConfiguration())
+	if err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+	results, err := s.Simulate(context.TODO(), "default/placement1")
+	if err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+
+	out := &bytes.Buffer{}
+	if err := Print(out, results, output.Table); err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+	expected := `Placement default/placement1: 1 decisions, 0 unscheduled
+Eligible clustersets: prod (3 clusters)
+
+FILTER             CLUSTERS  FILTERED OUT
+Predicate          2         1
+TaintToleration    2         0
+KubernetesVersion  2         0
+CELPredicate       2         0
+
+CLUSTER   Balance(1)  Steady(1)  TaintToleration(1)  TOTAL  DECISION
+cluster2  100         100        0                   200    Steady
+cluster1  100         0          0                   100    -
+`
+	if out.String() != expected {
+		t.Errorf("expected output:\n%s\nbut got:\n%s", expected, out.String())
+	}
+
+	for _, format := range []string{output.JSON, output.YAML} {
+		out.Reset()
+		if err := Print(out, results, format); err != nil {
+			t.Errorf("unexpected err: %v", err)
+		}
+		if !strings.Contains(out.String(), "placement1") {
+			t.Errorf("expected placement1 in %s output, but got %s", format, out.String())
+		}
+	}
+	if err := Print(out, results, "xml"); err == nil {
+		t.Errorf("expected error for an unsupported output format")
+	}
+}
@@ -3,6 +3,7 @@ package benchmark
 import (
 	"context"
 	"fmt"
+	"os"
 	"path/filepath"
 	"testing"
 	"time"
@@ -80,18 +81,26 @@ var benchmarkPlacement = clusterapiv1beta1.Placement{
 }
 
 func BenchmarkSchedulePlacements100(b *testing.B) {
-	benchmarkSchedulePlacements(b, 100, 1)
+	benchmarkSchedulePlacements(b, 100, 1, 0)
 }
 
 func BenchmarkSchedulePlacements1000(b *testing.B) {
-	benchmarkSchedulePlacements(b, 1000, 1000)
+	benchmarkSchedulePlacements(b, 1000, 1000, 0)
 }
 
 func BenchmarkSchedulePlacements10000(b *testing.B) {
-	benchmarkSchedulePlacements(b, 10000, 1000)
+	benchmarkSchedulePlacements(b, 10000, 1000, 0)
 }
 
-func benchmarkSchedulePlacements(b *testing.B, pnum, cnum int) {
+// BenchmarkSchedulePlacements1000Sequential runs the plugins sequentially to compare with
+// BenchmarkSchedulePlacements1000, which uses the default parallelism.
+func BenchmarkSchedulePlacements1000Sequential(b *testing.B) {
+	benchmarkSchedulePlacements(b, 1000, 1000, 1)
+}
+
+// benchmarkSchedulePlacements schedules pnum placements on cnum clusters. The default
+// parallelism of the scheduler is used if parallelism is 0.
+func benchmarkSchedulePlacements(b *testing.B, pnum, cnum int, parallelism int32) {
 	var err error
 	ctx, cancel := context.WithCancel(context.Background())
 	scheduling.ResyncInterval = time.Second * 5
@@ -120,8 +129,18 @@ func benchmarkSchedulePlacements(b *testing.B, pnum, cnum int) {
 	createClusters(namespace, name, cnum)
 	createAddOnPlacementScores("demo", cnum)
 
+	options := controllers.NewPlacementControllerOptions()
+	if parallelism > 0 {
+		options.SchedulerConfigFile = filepath.Join(b.TempDir(), "scheduler-config.yaml")
+		config := fmt.Sprintf("apiVersion: %s\nkind: %s\nparallelism: %d\n",
+			scheduling.SchedulerConfigurationAPIVersion, scheduling.SchedulerConfigurationKind, parallelism)
+		if err := os.WriteFile(options.SchedulerConfigFile, []byte(config), 0600); err != nil {
+			klog.Fatalf("%v", err)
+		}
+	}
+
 	b.ResetTimer()
-	go controllers.RunControllerManager(ctx, &controllercmd.ControllerContext{
+	go options.RunControllerManager(ctx, &controllercmd.ControllerContext{
 		KubeConfig:    cfg,
 		EventRecorder: util.NewIntegrationTestEventRecorder("integration"),
 	})